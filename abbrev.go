@@ -0,0 +1,98 @@
+// Copyright 2020 The vine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cli
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// abbreviatedCommand returns the visible command having a name or an alias
+// starting with prefix, an error listing the candidates if there are more
+// than one
+func (a *App) abbreviatedCommand(prefix string) (*Command, error) {
+	if prefix == "" {
+		return nil, nil
+	}
+
+	var matches []*Command
+	for _, c := range a.Commands {
+		if c.Hidden {
+			continue
+		}
+		for _, name := range c.Names() {
+			if strings.HasPrefix(name, prefix) {
+				matches = append(matches, c)
+				break
+			}
+		}
+	}
+
+	switch len(matches) {
+	case 0:
+		return nil, nil
+	case 1:
+		return matches[0], nil
+	}
+
+	var names []string
+	for _, c := range matches {
+		names = append(names, c.Name)
+	}
+	return nil, fmt.Errorf("ambiguous command %q, could be one of: %s", prefix, strings.Join(names, ", "))
+}
+
+// checkAmbiguousCommand returns the error of an ambiguous abbreviation of
+// the command named name
+func (a *App) checkAmbiguousCommand(name string) error {
+	if !a.AllowAbbreviations || a.exactCommand(name) != nil {
+		return nil
+	}
+	_, err := a.abbreviatedCommand(name)
+	return err
+}
+
+// expandFlagName returns the long name of the visible flag, local or
+// persistent, starting with prefix, an error listing the candidates if there
+// are more than one
+func expandFlagName(flags []Flag, persistent map[string]*persistentSet, prefix string) (string, error) {
+	candidates := visibleFlags(flags)
+	for _, ps := range persistent {
+		if !hasFlag(candidates, ps.flag) {
+			candidates = append(candidates, visibleFlags([]Flag{ps.flag})...)
+		}
+	}
+
+	var matches []string
+	for _, f := range candidates {
+		for _, name := range f.Names() {
+			if len(name) > 1 && strings.HasPrefix(name, prefix) {
+				matches = append(matches, name)
+				break
+			}
+		}
+	}
+
+	switch len(matches) {
+	case 0:
+		return prefix, nil
+	case 1:
+		return matches[0], nil
+	}
+
+	sort.Strings(matches)
+	return "", fmt.Errorf("ambiguous flag --%s, could be one of: --%s", prefix, strings.Join(matches, ", --"))
+}
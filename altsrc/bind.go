@@ -0,0 +1,67 @@
+// Copyright 2020 The vine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package altsrc
+
+import (
+	"github.com/lack-io/cli"
+)
+
+// FlagsFromStruct is like cli.FlagsFromStruct, but wraps the flags so that
+// they can be set from an input source as well. The input source keys are
+// the flag names, i.e. "db-host" for the Host field of a DB struct field.
+func FlagsFromStruct(v interface{}) ([]cli.Flag, error) {
+	flags, err := cli.FlagsFromStruct(v)
+	if err != nil {
+		return nil, err
+	}
+	for i, f := range flags {
+		flags[i] = wrapFlag(f)
+	}
+	return flags, nil
+}
+
+// wrapFlag returns the input source aware wrapper of f, or f itself if it
+// has none
+func wrapFlag(f cli.Flag) cli.Flag {
+	switch f := f.(type) {
+	case *cli.StringFlag:
+		return NewStringFlag(f)
+	case *cli.BoolFlag:
+		return NewBoolFlag(f)
+	case *cli.IntFlag:
+		return NewIntFlag(f)
+	case *cli.Int64Flag:
+		return NewInt64Flag(f)
+	case *cli.UintFlag:
+		return NewUintFlag(f)
+	case *cli.Uint64Flag:
+		return NewUint64Flag(f)
+	case *cli.Float64Flag:
+		return NewFloat64Flag(f)
+	case *cli.DurationFlag:
+		return NewDurationFlag(f)
+	case *cli.StringSliceFlag:
+		return NewStringSliceFlag(f)
+	case *cli.IntSliceFlag:
+		return NewIntSliceFlag(f)
+	case *cli.Int64SliceFlag:
+		return NewInt64SliceFlag(f)
+	case *cli.Float64SliceFlag:
+		return NewFloat64SliceFlag(f)
+	case *cli.StringMapFlag:
+		return NewStringMapFlag(f)
+	}
+	return f
+}
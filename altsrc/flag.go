@@ -33,21 +33,49 @@ type FlagInputSourceExtension interface {
 // ApplyInputSourceValues iterates over all provided flags and
 // executes ApplyInputSourceValue on flags implementing the
 // FlagInputSourceExtension interface to initialize these flags
-// to an alternate input source.
+// to an alternate input source. The values taken from the input
+// source are recorded as the source of these flags and checked by
+// the flag validators, then the actions of these flags are run.
 func ApplyInputSourceValues(context *cli.Context, inputSourceContext InputSourceContext, flags []cli.Flag) error {
+	var applied []cli.Flag
 	for _, f := range flags {
 		inputSourceExtendedFlag, isType := f.(FlagInputSourceExtension)
 		if isType {
+			wasSet := isFlagSet(context, f)
 			err := inputSourceExtendedFlag.ApplyInputSourceValue(context, inputSourceContext)
 			if err != nil {
 				return err
 			}
+			if !wasSet && isFlagSet(context, f) {
+				name := f.Names()[0]
+				source := cli.ValueSource{Kind: cli.AltInputSource, Name: inputSourceContext.Source(), Key: name}
+				context.SetSource(name, source)
+				if err := cli.ValidateFlag(context, f, source.String()); err != nil {
+					return err
+				}
+				applied = append(applied, f)
+			}
+		}
+	}
+
+	for _, f := range applied {
+		if err := cli.RunFlagAction(context, f); err != nil {
+			return err
 		}
 	}
 
 	return nil
 }
 
+func isFlagSet(context *cli.Context, f cli.Flag) bool {
+	for _, name := range f.Names() {
+		if context.IsSet(name) {
+			return true
+		}
+	}
+	return false
+}
+
 // InitInputSource is used to to setup an InputSourceContext on a cli.Command Before method. It will create a new
 // input source based on the func provided. If there is no error it will then apply the new input source to any flags
 // that are supported by the input source
@@ -104,12 +132,10 @@ func (f *StringSliceFlag) ApplyInputSourceValue(context *cli.Context, isc InputS
 				return err
 			}
 			if value != nil {
-				var sliceValue cli.StringSlice = *(cli.NewStringSlice(value...))
+				// set the serialized slice to keep the flag's destination
+				serialized := cli.NewStringSlice(value...).Serialize()
 				for _, name := range f.Names() {
-					underlyingFlag := f.set.Lookup(name)
-					if underlyingFlag != nil {
-						underlyingFlag.Value = &sliceValue
-					}
+					_ = f.set.Set(name, serialized)
 				}
 			}
 		}
@@ -126,12 +152,28 @@ func (f *IntSliceFlag) ApplyInputSourceValue(context *cli.Context, isc InputSour
 				return err
 			}
 			if value != nil {
-				var sliceValue cli.IntSlice = *(cli.NewIntSlice(value...))
+				serialized := cli.NewIntSlice(value...).Serialize()
 				for _, name := range f.Names() {
-					underlyingFlag := f.set.Lookup(name)
-					if underlyingFlag != nil {
-						underlyingFlag.Value = &sliceValue
-					}
+					_ = f.set.Set(name, serialized)
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// ApplyInputSourceValue applies a StringMap value to the flagSet if required
+func (f *StringMapFlag) ApplyInputSourceValue(context *cli.Context, isc InputSourceContext) error {
+	if f.set != nil {
+		if !context.IsSet(f.Name) && !isEnvVarSet(f.EnvVars) {
+			value, err := isc.StringMap(f.StringMapFlag.Name)
+			if err != nil {
+				return err
+			}
+			if value != nil {
+				serialized := cli.NewStringMap(value).Serialize()
+				for _, name := range f.Names() {
+					_ = f.set.Set(name, serialized)
 				}
 			}
 		}
@@ -221,6 +263,49 @@ func (f *IntFlag) ApplyInputSourceValue(context *cli.Context, isc InputSourceCon
 	return nil
 }
 
+// ApplyInputSourceValue applies a count value to the flagSet if required
+func (f *CountFlag) ApplyInputSourceValue(context *cli.Context, isc InputSourceContext) error {
+	if f.set != nil {
+		if !(context.IsSet(f.Name) || isEnvVarSet(f.EnvVars)) {
+			value, err := isc.Int(f.CountFlag.Name)
+			if err != nil {
+				return err
+			}
+			if value > 0 {
+				for _, name := range f.Names() {
+					_ = f.set.Set(name, strconv.FormatInt(int64(value), 10))
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// ApplyInputSourceValue applies a byte size value to the flagSet if required,
+// the source may hold either a plain number of bytes or a string with a unit
+func (f *ByteSizeFlag) ApplyInputSourceValue(context *cli.Context, isc InputSourceContext) error {
+	if f.set != nil {
+		if !(context.IsSet(f.Name) || isEnvVarSet(f.EnvVars)) {
+			value, err := isc.String(f.ByteSizeFlag.Name)
+			if err != nil {
+				intValue, intErr := isc.Int(f.ByteSizeFlag.Name)
+				if intErr != nil {
+					return err
+				}
+				value = strconv.FormatInt(int64(intValue), 10)
+			}
+			if value != "" && value != "0" {
+				for _, name := range f.Names() {
+					if err := f.set.Set(name, value); err != nil {
+						return err
+					}
+				}
+			}
+		}
+	}
+	return nil
+}
+
 // ApplyInputSourceValue applies a Duration value to the flagSet if required
 func (f *DurationFlag) ApplyInputSourceValue(context *cli.Context, isc InputSourceContext) error {
 	if f.set != nil {
@@ -258,6 +343,166 @@ func (f *Float64Flag) ApplyInputSourceValue(context *cli.Context, isc InputSourc
 	return nil
 }
 
+// ApplyInputSourceValue applies a IP value to the flagSet if required
+func (f *IPFlag) ApplyInputSourceValue(context *cli.Context, isc InputSourceContext) error {
+	if f.set != nil {
+		if !(context.IsSet(f.Name) || isEnvVarSet(f.EnvVars)) {
+			value, err := isc.String(f.IPFlag.Name)
+			if err != nil {
+				return err
+			}
+			if value != "" {
+				for _, name := range f.Names() {
+					if err := f.set.Set(name, value); err != nil {
+						return err
+					}
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// ApplyInputSourceValue applies a IP slice value to the flagSet if required
+func (f *IPSliceFlag) ApplyInputSourceValue(context *cli.Context, isc InputSourceContext) error {
+	if f.set != nil {
+		if !(context.IsSet(f.Name) || isEnvVarSet(f.EnvVars)) {
+			value, err := isc.StringSlice(f.IPSliceFlag.Name)
+			if err != nil {
+				return err
+			}
+			// every name shares the same value, setting the items once
+			// validates and appends them
+			for _, item := range value {
+				if err := f.set.Set(f.Name, item); err != nil {
+					return err
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// ApplyInputSourceValue applies a CIDR value to the flagSet if required
+func (f *IPNetFlag) ApplyInputSourceValue(context *cli.Context, isc InputSourceContext) error {
+	if f.set != nil {
+		if !(context.IsSet(f.Name) || isEnvVarSet(f.EnvVars)) {
+			value, err := isc.String(f.IPNetFlag.Name)
+			if err != nil {
+				return err
+			}
+			if value != "" {
+				for _, name := range f.Names() {
+					if err := f.set.Set(name, value); err != nil {
+						return err
+					}
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// ApplyInputSourceValue applies a CIDR slice value to the flagSet if required
+func (f *IPNetSliceFlag) ApplyInputSourceValue(context *cli.Context, isc InputSourceContext) error {
+	if f.set != nil {
+		if !(context.IsSet(f.Name) || isEnvVarSet(f.EnvVars)) {
+			value, err := isc.StringSlice(f.IPNetSliceFlag.Name)
+			if err != nil {
+				return err
+			}
+			// every name shares the same value, setting the items once
+			// validates and appends them
+			for _, item := range value {
+				if err := f.set.Set(f.Name, item); err != nil {
+					return err
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// ApplyInputSourceValue applies a host:port value to the flagSet if required
+func (f *HostPortFlag) ApplyInputSourceValue(context *cli.Context, isc InputSourceContext) error {
+	if f.set != nil {
+		if !(context.IsSet(f.Name) || isEnvVarSet(f.EnvVars)) {
+			value, err := isc.String(f.HostPortFlag.Name)
+			if err != nil {
+				return err
+			}
+			if value != "" {
+				for _, name := range f.Names() {
+					if err := f.set.Set(name, value); err != nil {
+						return err
+					}
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// ApplyInputSourceValue applies a host:port slice value to the flagSet if required
+func (f *HostPortSliceFlag) ApplyInputSourceValue(context *cli.Context, isc InputSourceContext) error {
+	if f.set != nil {
+		if !(context.IsSet(f.Name) || isEnvVarSet(f.EnvVars)) {
+			value, err := isc.StringSlice(f.HostPortSliceFlag.Name)
+			if err != nil {
+				return err
+			}
+			// every name shares the same value, setting the items once
+			// validates and appends them
+			for _, item := range value {
+				if err := f.set.Set(f.Name, item); err != nil {
+					return err
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// ApplyInputSourceValue applies a URL value to the flagSet if required
+func (f *URLFlag) ApplyInputSourceValue(context *cli.Context, isc InputSourceContext) error {
+	if f.set != nil {
+		if !(context.IsSet(f.Name) || isEnvVarSet(f.EnvVars)) {
+			value, err := isc.String(f.URLFlag.Name)
+			if err != nil {
+				return err
+			}
+			if value != "" {
+				for _, name := range f.Names() {
+					if err := f.set.Set(name, value); err != nil {
+						return err
+					}
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// ApplyInputSourceValue applies a URL slice value to the flagSet if required
+func (f *URLSliceFlag) ApplyInputSourceValue(context *cli.Context, isc InputSourceContext) error {
+	if f.set != nil {
+		if !(context.IsSet(f.Name) || isEnvVarSet(f.EnvVars)) {
+			value, err := isc.StringSlice(f.URLSliceFlag.Name)
+			if err != nil {
+				return err
+			}
+			// every name shares the same value, setting the items once
+			// validates and appends them
+			for _, item := range value {
+				if err := f.set.Set(f.Name, item); err != nil {
+					return err
+				}
+			}
+		}
+	}
+	return nil
+}
+
 func isEnvVarSet(envVars []string) bool {
 	for _, envVar := range envVars {
 		if _, ok := syscall.Getenv(envVar); ok {
@@ -41,6 +41,44 @@ func (f *BoolFlag) Apply(set *flag.FlagSet) error {
 	return f.BoolFlag.Apply(set)
 }
 
+// ByteSizeFlag is the flag type that wraps cli.ByteSizeFlag to allow
+// for other values to be specified
+type ByteSizeFlag struct {
+	*cli.ByteSizeFlag
+	set *flag.FlagSet
+}
+
+// NewByteSizeFlag creates a new ByteSizeFlag
+func NewByteSizeFlag(fl *cli.ByteSizeFlag) *ByteSizeFlag {
+	return &ByteSizeFlag{ByteSizeFlag: fl, set: nil}
+}
+
+// Apply saves the flagSet for later usage calls, then calls
+// the wrapped ByteSizeFlag.Apply
+func (f *ByteSizeFlag) Apply(set *flag.FlagSet) error {
+	f.set = set
+	return f.ByteSizeFlag.Apply(set)
+}
+
+// CountFlag is the flag type that wraps cli.CountFlag to allow
+// for other values to be specified
+type CountFlag struct {
+	*cli.CountFlag
+	set *flag.FlagSet
+}
+
+// NewCountFlag creates a new CountFlag
+func NewCountFlag(fl *cli.CountFlag) *CountFlag {
+	return &CountFlag{CountFlag: fl, set: nil}
+}
+
+// Apply saves the flagSet for later usage calls, then calls
+// the wrapped CountFlag.Apply
+func (f *CountFlag) Apply(set *flag.FlagSet) error {
+	f.set = set
+	return f.CountFlag.Apply(set)
+}
+
 // DurationFlag is the flag type that wraps cli.DurationFlag to allow
 // for other values to be specified
 type DurationFlag struct {
@@ -98,6 +136,120 @@ func (f *GenericFlag) Apply(set *flag.FlagSet) error {
 	return f.GenericFlag.Apply(set)
 }
 
+// HostPortFlag is the flag type that wraps cli.HostPortFlag to allow
+// for other values to be specified
+type HostPortFlag struct {
+	*cli.HostPortFlag
+	set *flag.FlagSet
+}
+
+// NewHostPortFlag creates a new HostPortFlag
+func NewHostPortFlag(fl *cli.HostPortFlag) *HostPortFlag {
+	return &HostPortFlag{HostPortFlag: fl, set: nil}
+}
+
+// Apply saves the flagSet for later usage calls, then calls
+// the wrapped HostPortFlag.Apply
+func (f *HostPortFlag) Apply(set *flag.FlagSet) error {
+	f.set = set
+	return f.HostPortFlag.Apply(set)
+}
+
+// HostPortSliceFlag is the flag type that wraps cli.HostPortSliceFlag to allow
+// for other values to be specified
+type HostPortSliceFlag struct {
+	*cli.HostPortSliceFlag
+	set *flag.FlagSet
+}
+
+// NewHostPortSliceFlag creates a new HostPortSliceFlag
+func NewHostPortSliceFlag(fl *cli.HostPortSliceFlag) *HostPortSliceFlag {
+	return &HostPortSliceFlag{HostPortSliceFlag: fl, set: nil}
+}
+
+// Apply saves the flagSet for later usage calls, then calls
+// the wrapped HostPortSliceFlag.Apply
+func (f *HostPortSliceFlag) Apply(set *flag.FlagSet) error {
+	f.set = set
+	return f.HostPortSliceFlag.Apply(set)
+}
+
+// IPFlag is the flag type that wraps cli.IPFlag to allow
+// for other values to be specified
+type IPFlag struct {
+	*cli.IPFlag
+	set *flag.FlagSet
+}
+
+// NewIPFlag creates a new IPFlag
+func NewIPFlag(fl *cli.IPFlag) *IPFlag {
+	return &IPFlag{IPFlag: fl, set: nil}
+}
+
+// Apply saves the flagSet for later usage calls, then calls
+// the wrapped IPFlag.Apply
+func (f *IPFlag) Apply(set *flag.FlagSet) error {
+	f.set = set
+	return f.IPFlag.Apply(set)
+}
+
+// IPSliceFlag is the flag type that wraps cli.IPSliceFlag to allow
+// for other values to be specified
+type IPSliceFlag struct {
+	*cli.IPSliceFlag
+	set *flag.FlagSet
+}
+
+// NewIPSliceFlag creates a new IPSliceFlag
+func NewIPSliceFlag(fl *cli.IPSliceFlag) *IPSliceFlag {
+	return &IPSliceFlag{IPSliceFlag: fl, set: nil}
+}
+
+// Apply saves the flagSet for later usage calls, then calls
+// the wrapped IPSliceFlag.Apply
+func (f *IPSliceFlag) Apply(set *flag.FlagSet) error {
+	f.set = set
+	return f.IPSliceFlag.Apply(set)
+}
+
+// IPNetFlag is the flag type that wraps cli.IPNetFlag to allow
+// for other values to be specified
+type IPNetFlag struct {
+	*cli.IPNetFlag
+	set *flag.FlagSet
+}
+
+// NewIPNetFlag creates a new IPNetFlag
+func NewIPNetFlag(fl *cli.IPNetFlag) *IPNetFlag {
+	return &IPNetFlag{IPNetFlag: fl, set: nil}
+}
+
+// Apply saves the flagSet for later usage calls, then calls
+// the wrapped IPNetFlag.Apply
+func (f *IPNetFlag) Apply(set *flag.FlagSet) error {
+	f.set = set
+	return f.IPNetFlag.Apply(set)
+}
+
+// IPNetSliceFlag is the flag type that wraps cli.IPNetSliceFlag to allow
+// for other values to be specified
+type IPNetSliceFlag struct {
+	*cli.IPNetSliceFlag
+	set *flag.FlagSet
+}
+
+// NewIPNetSliceFlag creates a new IPNetSliceFlag
+func NewIPNetSliceFlag(fl *cli.IPNetSliceFlag) *IPNetSliceFlag {
+	return &IPNetSliceFlag{IPNetSliceFlag: fl, set: nil}
+}
+
+// Apply saves the flagSet for later usage calls, then calls
+// the wrapped IPNetSliceFlag.Apply
+func (f *IPNetSliceFlag) Apply(set *flag.FlagSet) error {
+	f.set = set
+	return f.IPNetSliceFlag.Apply(set)
+}
+
 // Int64Flag is the flag type that wraps cli.Int64Flag to allow
 // for other values to be specified
 type Int64Flag struct {
@@ -250,6 +402,25 @@ func (f *StringSliceFlag) Apply(set *flag.FlagSet) error {
 	return f.StringSliceFlag.Apply(set)
 }
 
+// StringMapFlag is the flag type that wraps cli.StringMapFlag to allow
+// for other values to be specified
+type StringMapFlag struct {
+	*cli.StringMapFlag
+	set *flag.FlagSet
+}
+
+// NewStringMapFlag creates a new StringMapFlag
+func NewStringMapFlag(fl *cli.StringMapFlag) *StringMapFlag {
+	return &StringMapFlag{StringMapFlag: fl, set: nil}
+}
+
+// Apply saves the flagSet for later usage calls, then calls
+// the wrapped StringMapFlag.Apply
+func (f *StringMapFlag) Apply(set *flag.FlagSet) error {
+	f.set = set
+	return f.StringMapFlag.Apply(set)
+}
+
 // Uint64Flag is the flag type that wraps cli.Uint64Flag to allow
 // for other values to be specified
 type Uint64Flag struct {
@@ -287,3 +458,41 @@ func (f *UintFlag) Apply(set *flag.FlagSet) error {
 	f.set = set
 	return f.UintFlag.Apply(set)
 }
+
+// URLFlag is the flag type that wraps cli.URLFlag to allow
+// for other values to be specified
+type URLFlag struct {
+	*cli.URLFlag
+	set *flag.FlagSet
+}
+
+// NewURLFlag creates a new URLFlag
+func NewURLFlag(fl *cli.URLFlag) *URLFlag {
+	return &URLFlag{URLFlag: fl, set: nil}
+}
+
+// Apply saves the flagSet for later usage calls, then calls
+// the wrapped URLFlag.Apply
+func (f *URLFlag) Apply(set *flag.FlagSet) error {
+	f.set = set
+	return f.URLFlag.Apply(set)
+}
+
+// URLSliceFlag is the flag type that wraps cli.URLSliceFlag to allow
+// for other values to be specified
+type URLSliceFlag struct {
+	*cli.URLSliceFlag
+	set *flag.FlagSet
+}
+
+// NewURLSliceFlag creates a new URLSliceFlag
+func NewURLSliceFlag(fl *cli.URLSliceFlag) *URLSliceFlag {
+	return &URLSliceFlag{URLSliceFlag: fl, set: nil}
+}
+
+// Apply saves the flagSet for later usage calls, then calls
+// the wrapped URLSliceFlag.Apply
+func (f *URLSliceFlag) Apply(set *flag.FlagSet) error {
+	f.set = set
+	return f.URLSliceFlag.Apply(set)
+}
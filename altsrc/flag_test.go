@@ -17,6 +17,7 @@ package altsrc
 import (
 	"flag"
 	"fmt"
+	"net"
 	"os"
 	"runtime"
 	"strings"
@@ -262,6 +263,121 @@ func TestIntApplyInputSourceMethodEnvVarSet(t *testing.T) {
 	expect(t, 12, c.Int("test"))
 }
 
+func TestStringMapApplyInputSourceValue(t *testing.T) {
+	c := runTest(t, testApplyInputSource{
+		Flag:     NewStringMapFlag(&cli.StringMapFlag{Name: "test"}),
+		FlagName: "test",
+		MapValue: map[interface{}]interface{}{"a": "1", "b": 2},
+	})
+	expect(t, c.StringMap("test"), map[string]string{"a": "1", "b": "2"})
+}
+
+func TestStringMapApplyInputSourceMethodContextSet(t *testing.T) {
+	c := runTest(t, testApplyInputSource{
+		Flag:               NewStringMapFlag(&cli.StringMapFlag{Name: "test"}),
+		FlagName:           "test",
+		MapValue:           map[interface{}]interface{}{"a": "1"},
+		ContextValueString: "c=3",
+	})
+	expect(t, c.StringMap("test"), map[string]string{"c": "3"})
+}
+
+func TestStringMapApplyInputSourceMethodEnvVarSet(t *testing.T) {
+	c := runTest(t, testApplyInputSource{
+		Flag:        NewStringMapFlag(&cli.StringMapFlag{Name: "test", EnvVars: []string{"TEST"}}),
+		FlagName:    "test",
+		MapValue:    map[interface{}]interface{}{"a": "1"},
+		EnvVarName:  "TEST",
+		EnvVarValue: "A=1,B=2",
+	})
+	expect(t, c.StringMap("test"), map[string]string{"A": "1", "B": "2"})
+}
+
+func TestCountApplyInputSourceMethodSet(t *testing.T) {
+	c := runTest(t, testApplyInputSource{
+		Flag:     NewCountFlag(&cli.CountFlag{Name: "test"}),
+		FlagName: "test",
+		MapValue: 3,
+	})
+	expect(t, 3, c.Count("test"))
+}
+
+func TestCountApplyInputSourceMethodEnvVarSet(t *testing.T) {
+	c := runTest(t, testApplyInputSource{
+		Flag:        NewCountFlag(&cli.CountFlag{Name: "test", EnvVars: []string{"TEST"}}),
+		FlagName:    "test",
+		MapValue:    3,
+		EnvVarName:  "TEST",
+		EnvVarValue: "2",
+	})
+	expect(t, 2, c.Count("test"))
+}
+
+func TestByteSizeApplyInputSourceMethodSet(t *testing.T) {
+	c := runTest(t, testApplyInputSource{
+		Flag:     NewByteSizeFlag(&cli.ByteSizeFlag{Name: "test"}),
+		FlagName: "test",
+		MapValue: "10MiB",
+	})
+	expect(t, uint64(10<<20), c.ByteSize("test"))
+
+	c = runTest(t, testApplyInputSource{
+		Flag:     NewByteSizeFlag(&cli.ByteSizeFlag{Name: "test"}),
+		FlagName: "test",
+		MapValue: 4096,
+	})
+	expect(t, uint64(4096), c.ByteSize("test"))
+}
+
+func TestByteSizeApplyInputSourceMethodContextSet(t *testing.T) {
+	c := runTest(t, testApplyInputSource{
+		Flag:               NewByteSizeFlag(&cli.ByteSizeFlag{Name: "test"}),
+		FlagName:           "test",
+		MapValue:           "10MiB",
+		ContextValueString: "1K",
+	})
+	expect(t, uint64(1000), c.ByteSize("test"))
+}
+
+func TestIPApplyInputSourceMethodSet(t *testing.T) {
+	c := runTest(t, testApplyInputSource{
+		Flag:     NewIPFlag(&cli.IPFlag{Name: "test"}),
+		FlagName: "test",
+		MapValue: "10.0.0.1",
+	})
+	expect(t, net.ParseIP("10.0.0.1"), c.IP("test"))
+}
+
+func TestURLApplyInputSourceMethodInvalid(t *testing.T) {
+	set := flag.NewFlagSet("test", flag.ContinueOnError)
+	c := cli.NewContext(nil, set, nil)
+	f := NewURLFlag(&cli.URLFlag{Name: "test", Schemes: []string{"https"}})
+	_ = f.Apply(set)
+
+	err := f.ApplyInputSourceValue(c, &MapInputSource{valueMap: map[interface{}]interface{}{"test": "http://example.com"}})
+	expect(t, err.Error(), "invalid URL \"http://example.com\": scheme must be one of https")
+}
+
+func TestHostPortSliceApplyInputSourceValue(t *testing.T) {
+	c := runTest(t, testApplyInputSource{
+		Flag:     NewHostPortSliceFlag(&cli.HostPortSliceFlag{Name: "test"}),
+		FlagName: "test",
+		MapValue: []interface{}{"a:1", "b:2"},
+	})
+	expect(t, c.HostPortSlice("test"), []string{"a:1", "b:2"})
+}
+
+func TestIPNetSliceApplyInputSourceMethodContextSet(t *testing.T) {
+	c := runTest(t, testApplyInputSource{
+		Flag:               NewIPNetSliceFlag(&cli.IPNetSliceFlag{Name: "test"}),
+		FlagName:           "test",
+		MapValue:           []interface{}{"10.0.0.0/8"},
+		ContextValueString: "192.168.0.0/16",
+	})
+	expect(t, len(c.IPNetSlice("test")), 1)
+	expect(t, c.IPNetSlice("test")[0].String(), "192.168.0.0/16")
+}
+
 func TestDurationApplyInputSourceMethodSet(t *testing.T) {
 	c := runTest(t, testApplyInputSource{
 		Flag:     NewDurationFlag(&cli.DurationFlag{Name: "test"}),
@@ -322,6 +438,49 @@ func TestFloat64ApplyInputSourceMethodEnvVarSet(t *testing.T) {
 	expect(t, 1.4, c.Float64("test"))
 }
 
+func TestApplyInputSourceValuesValidates(t *testing.T) {
+	set := flag.NewFlagSet("test", flag.ContinueOnError)
+	c := cli.NewContext(nil, set, nil)
+	flags := []cli.Flag{
+		NewIntFlag(&cli.IntFlag{Name: "port", Validator: cli.Min(1024)}),
+		NewStringFlag(&cli.StringFlag{Name: "name", Validator: cli.MinLen(3)}),
+	}
+	for _, f := range flags {
+		_ = f.Apply(set)
+	}
+	_ = set.Set("name", "ab")
+
+	isc := &MapInputSource{file: "config.yaml", valueMap: map[interface{}]interface{}{"port": 80, "name": "gopher"}}
+	err := ApplyInputSourceValues(c, isc, flags)
+	expect(t, err.Error(), "invalid value \"80\" for flag --port (from config.yaml key port): must be at least 1024")
+}
+
+func TestApplyInputSourceValuesRunsFlagActions(t *testing.T) {
+	set := flag.NewFlagSet("test", flag.ContinueOnError)
+	c := cli.NewContext(nil, set, nil)
+	var called []string
+	action := func(c *cli.Context, value interface{}) error {
+		called = append(called, fmt.Sprintf("%v", value))
+		return nil
+	}
+	flags := []cli.Flag{
+		NewIntFlag(&cli.IntFlag{Name: "port", Action: action}),
+		NewStringFlag(&cli.StringFlag{Name: "name", Action: action}),
+		NewStringFlag(&cli.StringFlag{Name: "host", Action: action}),
+	}
+	for _, f := range flags {
+		_ = f.Apply(set)
+	}
+	_ = set.Set("name", "ab")
+
+	isc := &MapInputSource{file: "config.yaml", valueMap: map[interface{}]interface{}{"port": 8080, "name": "gopher"}}
+	err := ApplyInputSourceValues(c, isc, flags)
+	expect(t, err, nil)
+	expect(t, called, []string{"8080"})
+	expect(t, c.Source("port"), cli.ValueSource{Kind: cli.AltInputSource, Name: "config.yaml", Key: "port"})
+	expect(t, c.Source("name"), cli.ValueSource{Kind: cli.CommandLineSource})
+}
+
 func runTest(t *testing.T, test testApplyInputSource) *cli.Context {
 	inputSource := &MapInputSource{
 		file:     test.SourcePath,
@@ -34,6 +34,7 @@ type InputSourceContext interface {
 	String(name string) (string, error)
 	StringSlice(name string) ([]string, error)
 	IntSlice(name string) ([]int, error)
+	StringMap(name string) (map[string]string, error)
 	Generic(name string) (cli.Generic, error)
 	Bool(name string) (bool, error)
 }
@@ -326,6 +326,37 @@ func TestCommandJSONFileFlagHasDefaultGlobalEnvJSONSetGlobalEnvWinsNested(t *tes
 	expect(t, err, nil)
 }
 
+func TestCommandJSONFileStringMapNested(t *testing.T) {
+	app := &cli.App{}
+	set := flag.NewFlagSet("test", 0)
+	_ = ioutil.WriteFile("current.json", []byte(`{"top": {"labels": {"env": "prod", "replicas": 3}}}`), 0666)
+	defer os.Remove("current.json")
+
+	test := []string{"test-cmd", "--load", "current.json"}
+	_ = set.Parse(test)
+
+	c := cli.NewContext(app, set, nil)
+
+	command := &cli.Command{
+		Name:        "test-cmd",
+		Aliases:     []string{"tc"},
+		Usage:       "this is for testing",
+		Description: "testing",
+		Action: func(c *cli.Context) error {
+			val := c.StringMap("top.labels")
+			expect(t, val, map[string]string{"env": "prod", "replicas": "3"})
+			return nil
+		},
+		Flags: []cli.Flag{
+			NewStringMapFlag(&cli.StringMapFlag{Name: "top.labels"}),
+			&cli.StringFlag{Name: "load"}},
+	}
+	command.Before = InitInputSourceWithContext(command.Flags, NewJSONSourceFromFlagFunc("load"))
+	err := command.Run(c)
+
+	expect(t, err, nil)
+}
+
 func writeTempFile(t *testing.T, name string, content string) func() {
 	if err := ioutil.WriteFile(name, []byte(content), 0666); err != nil {
 		t.Fatalf("cannot write %q: %v", name, err)
@@ -170,6 +170,29 @@ func (x *jsonSource) IntSlice(name string) ([]int, error) {
 	}
 }
 
+func (x *jsonSource) StringMap(name string) (map[string]string, error) {
+	i, err := x.getValue(name)
+	if err != nil {
+		return nil, err
+	}
+	switch v := i.(type) {
+	default:
+		return nil, fmt.Errorf("unexpected type %T for %q", i, name)
+	case map[string]string:
+		return v, nil
+	case map[string]interface{}:
+		c := map[string]string{}
+		for k, s := range v {
+			switch s.(type) {
+			case map[string]interface{}, []interface{}:
+				return c, fmt.Errorf("unexpected item type %T in %T for %q", s, c, name)
+			}
+			c[k] = fmt.Sprint(s)
+		}
+		return c, nil
+	}
+}
+
 func (x *jsonSource) Generic(name string) (cli.Generic, error) {
 	i, err := x.getValue(name)
 	if err != nil {
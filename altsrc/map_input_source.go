@@ -209,6 +209,34 @@ func (fsm *MapInputSource) IntSlice(name string) ([]int, error) {
 	return intSlice, nil
 }
 
+// StringMap returns a map[string]string from the map if it exists otherwise returns nil
+func (fsm *MapInputSource) StringMap(name string) (map[string]string, error) {
+	otherGenericValue, exists := fsm.valueMap[name]
+	if !exists {
+		otherGenericValue, exists = nestedVal(name, fsm.valueMap)
+		if !exists {
+			return nil, nil
+		}
+	}
+
+	otherValue, isType := otherGenericValue.(map[interface{}]interface{})
+	if !isType {
+		return nil, incorrectTypeForFlagError(name, "map[interface{}]interface{}", otherGenericValue)
+	}
+
+	var stringMap = make(map[string]string, len(otherValue))
+	for k, v := range otherValue {
+		switch v.(type) {
+		case map[interface{}]interface{}, []interface{}:
+			return nil, incorrectTypeForFlagError(fmt.Sprintf("%s.%v", name, k), "string", v)
+		}
+
+		stringMap[fmt.Sprint(k)] = fmt.Sprint(v)
+	}
+
+	return stringMap, nil
+}
+
 // Generic returns an cli.Generic from the map if it exists otherwise returns nil
 func (fsm *MapInputSource) Generic(name string) (cli.Generic, error) {
 	otherGenericValue, exists := fsm.valueMap[name]
@@ -317,3 +317,34 @@ func TestCommandTomlFileFlagHasDefaultGlobalEnvTomlSetGlobalEnvWinsNested(t *tes
 
 	expect(t, err, nil)
 }
+
+func TestCommandTomlFileStringMapNested(t *testing.T) {
+	app := &cli.App{}
+	set := flag.NewFlagSet("test", 0)
+	_ = ioutil.WriteFile("current.toml", []byte("[top.labels]\nenv = \"prod\"\nreplicas = 3"), 0666)
+	defer os.Remove("current.toml")
+
+	test := []string{"test-cmd", "--load", "current.toml"}
+	_ = set.Parse(test)
+
+	c := cli.NewContext(app, set, nil)
+
+	command := &cli.Command{
+		Name:        "test-cmd",
+		Aliases:     []string{"tc"},
+		Usage:       "this is for testing",
+		Description: "testing",
+		Action: func(c *cli.Context) error {
+			val := c.StringMap("top.labels")
+			expect(t, val, map[string]string{"env": "prod", "replicas": "3"})
+			return nil
+		},
+		Flags: []cli.Flag{
+			NewStringMapFlag(&cli.StringMapFlag{Name: "top.labels"}),
+			&cli.StringFlag{Name: "load"}},
+	}
+	command.Before = InitInputSourceWithContext(command.Flags, NewTomlSourceFromFlagFunc("load"))
+	err := command.Run(c)
+
+	expect(t, err, nil)
+}
@@ -320,3 +320,76 @@ func TestCommandYamlFileFlagHasDefaultGlobalEnvYamlSetGlobalEnvWinsNested(t *tes
 
 	expect(t, err, nil)
 }
+
+func TestCommandYamlFileStringMapNested(t *testing.T) {
+	app := &cli.App{}
+	set := flag.NewFlagSet("test", 0)
+	_ = ioutil.WriteFile("current.yaml", []byte("top:\n  labels:\n    env: prod\n    replicas: 3"), 0666)
+	defer os.Remove("current.yaml")
+
+	test := []string{"test-cmd", "--load", "current.yaml"}
+	_ = set.Parse(test)
+
+	c := cli.NewContext(app, set, nil)
+
+	command := &cli.Command{
+		Name:        "test-cmd",
+		Aliases:     []string{"tc"},
+		Usage:       "this is for testing",
+		Description: "testing",
+		Action: func(c *cli.Context) error {
+			val := c.StringMap("top.labels")
+			expect(t, val, map[string]string{"env": "prod", "replicas": "3"})
+			return nil
+		},
+		Flags: []cli.Flag{
+			NewStringMapFlag(&cli.StringMapFlag{Name: "top.labels"}),
+			&cli.StringFlag{Name: "load"}},
+	}
+	command.Before = InitInputSourceWithContext(command.Flags, NewYamlSourceFromFlagFunc("load"))
+	err := command.Run(c)
+
+	expect(t, err, nil)
+}
+
+func TestCommandYamlFileFlagsFromStruct(t *testing.T) {
+	app := &cli.App{}
+	set := flag.NewFlagSet("test", 0)
+	_ = ioutil.WriteFile("current.yaml", []byte("name: gopher\ntags: [a, b]\ndb-port: 5433"), 0666)
+	defer os.Remove("current.yaml")
+
+	test := []string{"test-cmd", "--load", "current.yaml", "--db-host", "db.local"}
+	_ = set.Parse(test)
+
+	c := cli.NewContext(app, set, nil)
+
+	var cfg struct {
+		Name string
+		Tags []string
+		DB   struct {
+			Host string
+			Port int `default:"5432"`
+		}
+	}
+	flags, err := FlagsFromStruct(&cfg)
+	expect(t, err, nil)
+
+	command := &cli.Command{
+		Name:        "test-cmd",
+		Aliases:     []string{"tc"},
+		Usage:       "this is for testing",
+		Description: "testing",
+		Action: func(c *cli.Context) error {
+			expect(t, cfg.Name, "gopher")
+			expect(t, cfg.Tags, []string{"a", "b"})
+			expect(t, cfg.DB.Host, "db.local")
+			expect(t, cfg.DB.Port, 5433)
+			return nil
+		},
+		Flags: append(flags, &cli.StringFlag{Name: "load"}),
+	}
+	command.Before = InitInputSourceWithContext(command.Flags, NewYamlSourceFromFlagFunc("load"))
+	err = command.Run(c)
+
+	expect(t, err, nil)
+}
@@ -16,6 +16,7 @@ package cli
 
 import (
 	"context"
+	"errors"
 	"flag"
 	"fmt"
 	"io"
@@ -42,6 +43,7 @@ func initCommandLine() {
 	CommandLine.CustomAppHelpTemplate = CommandLineHelpTemplate
 	CommandLine.Action = func(ctx *Context) error { return nil }
 	CommandLine.HideVersion = true
+	CommandLine.ErrorHandling = ExitOnError
 }
 
 func init() {
@@ -61,6 +63,8 @@ type App struct {
 	UsageText string
 	// Description of the program argument format.
 	ArgsUsage string
+	// List of positional arguments, generating ArgsUsage when it is empty
+	Arguments []Argument
 	// Version of the program
 	Version string
 	// Description of the program
@@ -69,12 +73,19 @@ type App struct {
 	Commands []*Command
 	// List of flags to parse
 	Flags []Flag
+	// Constraints between the flags, checked with the required flags
+	FlagGroups []FlagGroup
 	// Boolean to enable bash completion commands
 	EnableBashCompletion bool
 	// Boolean to hide built-in help command
 	HideHelp bool
 	// Boolean to hide built-in version flag and the VERSION section of help
 	HideVersion bool
+	// Boolean to disable the built-in hidden debug-flags flag
+	HideDebugFlags bool
+	// EnvPrefix binds every flag without EnvVars to the environment
+	// variable PREFIX_[COMMAND_]FLAG, as named by EnvVarNamer
+	EnvPrefix string
 	// categories contains the categorized commands and is populated on app startup
 	categories CommandCategories
 	// An action to execute when the shell completion flag is set
@@ -116,8 +127,55 @@ type App struct {
 	// single-character bool arguments into one
 	// i.e. foobar -o -v -> foobar -ov
 	UseShortOptionHandling bool
+	// How Parse behaves if the parse fails
+	ErrorHandling ErrorHandling
+	// Boolean to exit through OsExiter once help or the version was shown,
+	// instead of returning ErrHelpShown
+	ExitOnHelp bool
+	// Boolean to make the use of deprecated flags and commands an error
+	// instead of a warning
+	StrictDeprecations bool
+	// Boolean to expand every "@path" argument into the arguments read
+	// from the file at path, split with shell-like quoting
+	ResponseFiles bool
+	// Boolean to accept any unambiguous prefix of the name or an alias of
+	// a command, and of the long name of a flag
+	AllowAbbreviations bool
+	// Boolean to disable the "Did you mean" suggestions of unknown
+	// commands and flags
+	DisableSuggestions bool
+	// Maximum edit distance of the suggested names, defaults to 2
+	SuggestionsMinDistance int
+	// Boolean to panic when the value of an undefined flag is looked up
+	// with the accessors of a Context, i.e. to catch misspelled names in
+	// tests. The Get accessors return an error instead.
+	StrictLookup bool
+	// Boolean to run Validate in Setup, the problems found are then
+	// returned by Run and Parse
+	ValidateOnSetup bool
+	// Boolean to recover from the panics in Before, Action and After,
+	// returning a *PanicError instead
+	RecoverPanics bool
+	// Exit code of the recovered panics, defaults to 1
+	PanicExitCode int
+	// Execute this function with the recovered panics
+	PanicHandler PanicHandlerFunc
+
+	// persistent maps the persistent flags of the ancestors to their flag
+	// sets while running as a subcommand
+	persistent map[string]*persistentSet
+	// inherited are the persistent flags of the parent commands, as shown
+	// in help
+	inherited []Flag
+	// parseContext holds the flags and the arguments parsed by Parse
+	parseContext *Context
+	parsed       bool
 
 	didSetup bool
+	// setupErr holds the problems found by Validate in Setup
+	setupErr error
+	// middlewares wrap the action of the app and of its commands
+	middlewares []Middleware
 }
 
 // Tries to find out when this binary was compiled.
@@ -197,7 +255,7 @@ func (a *App) Setup() {
 	}
 	a.Commands = newCommands
 
-	if a.Command(helpCommand.Name) == nil && !a.HideHelp {
+	if a.exactCommand(helpCommand.Name) == nil && !a.HideHelp {
 		a.appendCommand(helpCommand)
 
 		if HelpFlag != nil {
@@ -209,6 +267,16 @@ func (a *App) Setup() {
 		a.appendFlag(VersionFlag)
 	}
 
+	if !a.HideDebugFlags && DebugFlagsFlag != nil {
+		a.appendFlag(DebugFlagsFlag)
+	}
+
+	if a.RecoverPanics && TracePanicsFlag != nil {
+		a.appendFlag(TracePanicsFlag)
+	}
+
+	a.bindEnvVars()
+
 	a.categories = newCommandCategories()
 	for _, command := range a.Commands {
 		a.categories.AddCommand(command.Category, command)
@@ -222,6 +290,10 @@ func (a *App) Setup() {
 	if a.Writer == nil {
 		a.Writer = os.Stdout
 	}
+
+	if a.ValidateOnSetup {
+		a.setupErr = a.Validate()
+	}
 }
 
 func (a *App) newFlagSet() (*flag.FlagSet, error) {
@@ -232,6 +304,29 @@ func (a *App) useShortOptionHandling() bool {
 	return a.UseShortOptionHandling
 }
 
+func (a *App) isSubcommand(name string) bool {
+	return a.Command(name) != nil
+}
+
+func (a *App) readsValueFromFile(name string) bool {
+	return readsValueFromFile(a.Flags, a.persistent, name)
+}
+
+func (a *App) persistentFlagSet(name string) *persistentSet {
+	return a.persistent[name]
+}
+
+func (a *App) suggestFlags(name string) []string {
+	return suggestFlags(a.Flags, a.persistent, name, a.suggestionsDistance())
+}
+
+func (a *App) expandFlagName(name string) (string, error) {
+	if !a.AllowAbbreviations {
+		return name, nil
+	}
+	return expandFlagName(a.Flags, a.persistent, name)
+}
+
 // Run is the entry point to the cli app. Parses the arguments slice and routes
 // to the proper flag/args combination
 func (a *App) Run(arguments []string) (err error) {
@@ -243,6 +338,9 @@ func (a *App) Run(arguments []string) (err error) {
 // propagate timeouts and cancellation requests
 func (a *App) RunContext(ctx context.Context, arguments []string) (err error) {
 	a.Setup()
+	if a.setupErr != nil {
+		return a.setupErr
+	}
 
 	// handle the completion flag separately from the flagset since
 	// completion could be attempted after a flag, but before its value was put
@@ -252,6 +350,15 @@ func (a *App) RunContext(ctx context.Context, arguments []string) (err error) {
 	// always appends the completion flag at the end of the command
 	shellComplete, arguments := checkShellCompleteFlag(a, arguments)
 
+	if a.ResponseFiles && len(arguments) > 0 {
+		expanded, err := a.expandResponseFiles(arguments[1:])
+		if err != nil {
+			_, _ = fmt.Fprintf(a.Writer, "%s %s\n\n", "Incorrect Usage.", err.Error())
+			return err
+		}
+		arguments = append(arguments[:1:1], expanded...)
+	}
+
 	set, err := a.newFlagSet()
 	if err != nil {
 		return err
@@ -271,6 +378,10 @@ func (a *App) RunContext(ctx context.Context, arguments []string) (err error) {
 		return nil
 	}
 
+	if err == nil {
+		err = a.checkAmbiguousCommand(context.Args().First())
+	}
+
 	if err != nil {
 		if a.OnUsageError != nil {
 			err := a.OnUsageError(context, err, false)
@@ -284,22 +395,58 @@ func (a *App) RunContext(ctx context.Context, arguments []string) (err error) {
 
 	if !a.HideHelp && checkHelp(context) {
 		_ = ShowAppHelp(context)
-		os.Exit(0)
-		return nil
+		return helpShown(context)
 	}
 
 	if !a.HideVersion && checkVersion(context) {
 		ShowVersion(context)
-		os.Exit(0)
-		return nil
+		return helpShown(context)
 	}
 
-	cerr := checkRequiredFlags(a.Flags, context)
+	flags := checkedFlags(a.Flags, context, a.Command(context.Args().First()) != nil)
+	if derr := checkDeprecatedFlags(flags, context); derr != nil {
+		return derr
+	}
+
+	cerr := checkRequiredFlags(flags, context)
 	if cerr != nil {
 		_ = ShowAppHelp(context)
 		return cerr
 	}
 
+	if gerr := checkFlagGroups(a.FlagGroups, a.Flags, context); gerr != nil {
+		_ = ShowAppHelp(context)
+		return gerr
+	}
+
+	verr := checkFlagValidators(flags, context)
+	if verr == nil && a.Command(context.Args().First()) == nil {
+		verr = context.setArguments(a.Arguments)
+	}
+	if verr != nil {
+		if a.OnUsageError != nil {
+			err := a.OnUsageError(context, verr, false)
+			a.handleExitCoder(context, err)
+			return err
+		}
+		_, _ = fmt.Fprintf(a.Writer, "%s %s\n\n", "Incorrect Usage.", verr.Error())
+		_ = ShowAppHelp(context)
+		return verr
+	}
+
+	if ferr := runFlagActions(flags, context); ferr != nil {
+		a.handleExitCoder(context, ferr)
+		return ferr
+	}
+
+	if a.RecoverPanics {
+		defer func() {
+			if recovered := recover(); recovered != nil {
+				err = a.recoverPanic(context, recovered)
+			}
+		}()
+	}
+
 	if a.After != nil {
 		defer func() {
 			if afterErr := a.After(context); afterErr != nil {
@@ -330,14 +477,21 @@ func (a *App) RunContext(ctx context.Context, arguments []string) (err error) {
 		if c != nil {
 			return c.Run(context)
 		}
+		if a.hasDefaultAction() && len(a.VisibleCommands()) > 0 {
+			return a.commandNotFound(context, name, false)
+		}
 	}
 
 	if a.Action == nil {
 		a.Action = helpCommand.Action
 	}
 
+	if checkDebugFlags(context) {
+		return nil
+	}
+
 	// Run default Action
-	err = a.Action(context)
+	err = wrapAction(a.Action, a.middlewares)(context)
 
 	a.handleExitCoder(context, err)
 	return err
@@ -349,7 +503,7 @@ func (a *App) RunContext(ctx context.Context, arguments []string) (err error) {
 // to cli.App.Run. This will cause the application to exit with the given eror
 // code in the cli.ExitCoder
 func (a *App) RunAndExitOnError() {
-	if err := a.Run(os.Args); err != nil {
+	if err := a.Run(os.Args); err != nil && !errors.Is(err, ErrHelpShown) {
 		_, _ = fmt.Fprintln(a.errWriter(), err)
 		OsExiter(1)
 	}
@@ -362,7 +516,7 @@ func (a *App) RunAsSubcommand(ctx *Context) (err error) {
 
 	// append help to commands
 	if len(a.Commands) > 0 {
-		if a.Command(helpCommand.Name) == nil && !a.HideHelp {
+		if a.exactCommand(helpCommand.Name) == nil && !a.HideHelp {
 			a.appendCommand(helpCommand)
 
 			if HelpFlag != nil {
@@ -385,6 +539,7 @@ func (a *App) RunAsSubcommand(ctx *Context) (err error) {
 		return err
 	}
 
+	a.persistent = persistentFlagSets(ctx)
 	err = parseIter(set, a, ctx.Args().Tail(), ctx.shellComplete)
 	nerr := normalizeFlags(a.Flags, set)
 	context := NewContext(a, set, ctx)
@@ -404,6 +559,10 @@ func (a *App) RunAsSubcommand(ctx *Context) (err error) {
 		return nil
 	}
 
+	if err == nil {
+		err = a.checkAmbiguousCommand(context.Args().First())
+	}
+
 	if err != nil {
 		if a.OnUsageError != nil {
 			err = a.OnUsageError(context, err, true)
@@ -417,20 +576,50 @@ func (a *App) RunAsSubcommand(ctx *Context) (err error) {
 
 	if len(a.Commands) > 0 {
 		if checkSubcommandHelp(context) {
-			return nil
+			return helpShown(context)
 		}
 	} else {
 		if checkCommandHelp(ctx, context.Args().First()) {
-			return nil
+			return helpShown(context)
 		}
 	}
 
-	cerr := checkRequiredFlags(a.Flags, context)
+	flags := checkedFlags(a.Flags, context, a.Command(context.Args().First()) != nil)
+	if derr := checkDeprecatedFlags(flags, context); derr != nil {
+		return derr
+	}
+
+	cerr := checkRequiredFlags(flags, context)
 	if cerr != nil {
 		_ = ShowSubcommandHelp(context)
 		return cerr
 	}
 
+	if gerr := checkFlagGroups(a.FlagGroups, a.Flags, context); gerr != nil {
+		_ = ShowSubcommandHelp(context)
+		return gerr
+	}
+
+	verr := checkFlagValidators(flags, context)
+	if verr == nil && a.Command(context.Args().First()) == nil {
+		verr = context.setArguments(a.Arguments)
+	}
+	if verr != nil {
+		if a.OnUsageError != nil {
+			err = a.OnUsageError(context, verr, true)
+			a.handleExitCoder(context, err)
+			return err
+		}
+		_, _ = fmt.Fprintf(a.Writer, "%s %s\n\n", "Incorrect Usage.", verr.Error())
+		_ = ShowSubcommandHelp(context)
+		return verr
+	}
+
+	if ferr := runFlagActions(flags, context); ferr != nil {
+		a.handleExitCoder(context, ferr)
+		return ferr
+	}
+
 	if a.After != nil {
 		defer func() {
 			afterErr := a.After(context)
@@ -461,10 +650,17 @@ func (a *App) RunAsSubcommand(ctx *Context) (err error) {
 		if c != nil {
 			return c.Run(context)
 		}
+		if a.hasDefaultAction() && len(a.VisibleCommands()) > 0 {
+			return a.commandNotFound(context, name, true)
+		}
+	}
+
+	if checkDebugFlags(context) {
+		return nil
 	}
 
 	// Run default Action
-	err = a.Action(context)
+	err = wrapAction(a.Action, a.middlewares)(context)
 
 	a.handleExitCoder(context, err)
 	return err
@@ -472,6 +668,17 @@ func (a *App) RunAsSubcommand(ctx *Context) (err error) {
 
 // Command returns the named command on App. Returns nil if the command does not exist
 func (a *App) Command(name string) *Command {
+	if c := a.exactCommand(name); c != nil {
+		return c
+	}
+	if a.AllowAbbreviations {
+		c, _ := a.abbreviatedCommand(name)
+		return c
+	}
+	return nil
+}
+
+func (a *App) exactCommand(name string) *Command {
 	for _, c := range a.Commands {
 		if c.HasName(name) {
 			return c
@@ -501,18 +708,33 @@ func (a *App) VisibleCategories() []CommandCategory {
 func (a *App) VisibleCommands() []*Command {
 	var ret []*Command
 	for _, command := range a.Commands {
-		if !command.Hidden {
+		if !command.Hidden && !command.isDeprecated() {
 			ret = append(ret, command)
 		}
 	}
 	return ret
 }
 
+// ArgumentsUsage returns ArgsUsage, or the usage generated from the
+// Arguments when it is empty
+func (a *App) ArgumentsUsage() string {
+	if a.ArgsUsage == "" {
+		return argumentsUsage(a.Arguments)
+	}
+	return a.ArgsUsage
+}
+
 // VisibleFlags returns a slice of the Flags with Hidden=false
 func (a *App) VisibleFlags() []Flag {
 	return visibleFlags(a.Flags)
 }
 
+// VisibleInheritedFlags returns a slice of the persistent flags of the
+// parent commands with Hidden=false
+func (a *App) VisibleInheritedFlags() []Flag {
+	return visibleFlags(a.inherited)
+}
+
 func (a *App) errWriter() io.Writer {
 	// When the app ErrWriter is nil use the package level one.
 	if a.ErrWriter == nil {
@@ -11,6 +11,7 @@ import (
 	"reflect"
 	"strings"
 	"testing"
+	"time"
 )
 
 var (
@@ -153,30 +154,30 @@ func ExampleApp_Run_appHelp() {
 	}
 	_ = app.Run(os.Args)
 	// Output:
-	//NAME:
-	//	greet - A new cli application
+	// NAME:
+	//    greet - A new cli application
 	//
-	//USAGE:
-	//	greet [global options] command [command options] [arguments...]
+	// USAGE:
+	//    greet [global options] command [command options] [arguments...]
 	//
-	//VERSION:
-	//	0.1.0
+	// VERSION:
+	//    0.1.0
 	//
-	//DESCRIPTION:
-	//	This is how we describe greet the app
+	// DESCRIPTION:
+	//    This is how we describe greet the app
 	//
-	//AUTHORS:
-	//	Harrison <harrison@lolwut.com>
-	//	Oliver Allen <oliver@toyshop.com>
+	// AUTHORS:
+	//    Harrison <harrison@lolwut.com>
+	//    Oliver Allen <oliver@toyshop.com>
 	//
-	//COMMANDS:
-	//	describeit, d  use it to see a description
-	//	help, h        Shows a list of commands or help for one command
+	// COMMANDS:
+	//    describeit, d  use it to see a description
+	//    help, h        Shows a list of commands or help for one command
 	//
-	//	GLOBAL OPTIONS:
-	//	--name string  a name to say (default: "bob")
-	//	--help, -h     show help (default: false)
-	//	--version, -v  print the version (default: false)
+	// GLOBAL OPTIONS:
+	//    --name string  a name to say (default: "bob")
+	//    --help, -h     show help (default: false)
+	//    --version, -v  print the version (default: false)
 }
 
 func ExampleApp_Run_commandHelp() {
@@ -346,6 +347,26 @@ func ExampleApp_Run_bashComplete_withMultipleLongFlag() {
 	// --string-flag-2
 }
 
+func ExampleApp_Run_bashComplete_withEnumFlag() {
+	os.Args = []string{"greet", "--format", "--generate-bash-completion"}
+
+	app := NewApp()
+	app.Name = "greet"
+	app.EnableBashCompletion = true
+	app.Flags = []Flag{
+		&EnumFlag{
+			Name:    "format",
+			Allowed: []string{"json", "yaml", "table"},
+		},
+	}
+
+	_ = app.Run(os.Args)
+	// Output:
+	// json
+	// yaml
+	// table
+}
+
 func ExampleApp_Run_bashComplete() {
 	// set args for examples sake
 	// set args for examples sake
@@ -503,7 +524,7 @@ func TestApp_RunAsSubCommandIncorrectUsage(t *testing.T) {
 	a := App{
 		Name: "cmd",
 		Flags: []Flag{
-			&StringFlag{Name: "--foo"},
+			&StringFlag{Name: "foo"},
 		},
 		Writer: bytes.NewBufferString(""),
 	}
@@ -540,9 +561,88 @@ func TestApp_CommandWithFlagBeforeTerminator(t *testing.T) {
 	_ = app.Run([]string{"", "cmd", "--option", "my-option", "my-arg", "--", "--notARealFlag"})
 
 	expect(t, parsedOption, "my-option")
-	expect(t, args.Get(0), "my-arg")
-	expect(t, args.Get(1), "--")
-	expect(t, args.Get(2), "--notARealFlag")
+	expect(t, args.Slice(), []string{"my-arg", "--notARealFlag"})
+}
+
+func TestApp_InterleavedFlagsAndArgs(t *testing.T) {
+	var parsedOption string
+	var verbose bool
+	var args Args
+
+	app := &App{
+		Flags: []Flag{
+			&StringFlag{Name: "option"},
+			&BoolFlag{Name: "verbose"},
+		},
+		Action: func(c *Context) error {
+			parsedOption = c.String("option")
+			verbose = c.Bool("verbose")
+			args = c.Args()
+			return nil
+		},
+	}
+
+	err := app.Run([]string{"", "first", "--option=my-option", "second", "--verbose", "--", "--third"})
+
+	expect(t, err, nil)
+	expect(t, parsedOption, "my-option")
+	expect(t, verbose, true)
+	expect(t, args.Slice(), []string{"first", "second", "--third"})
+}
+
+func TestApp_ParsingStopsAtSubcommand(t *testing.T) {
+	var appOption, cmdOption string
+	var args Args
+
+	app := &App{
+		Flags: []Flag{
+			&StringFlag{Name: "option"},
+		},
+		Commands: []*Command{
+			{
+				Name: "cmd",
+				Flags: []Flag{
+					&StringFlag{Name: "option"},
+				},
+				Action: func(c *Context) error {
+					appOption = c.Lineage()[1].String("option")
+					cmdOption = c.String("option")
+					args = c.Args()
+					return nil
+				},
+			},
+		},
+	}
+
+	err := app.Run([]string{"", "--option", "app", "cmd", "arg", "--option", "cmd"})
+
+	expect(t, err, nil)
+	expect(t, appOption, "app")
+	expect(t, cmdOption, "cmd")
+	expect(t, args.Slice(), []string{"arg"})
+}
+
+func TestApp_NegatableBoolFlag(t *testing.T) {
+	os.Clearenv()
+	_ = os.Setenv("APP_CACHE", "true")
+
+	var cache, isSet bool
+	app := &App{
+		Flags: []Flag{
+			&BoolFlag{Name: "cache", EnvVars: []string{"APP_CACHE"}, Negatable: true},
+		},
+		Action: func(c *Context) error {
+			cache = c.Bool("cache")
+			isSet = c.IsSet("cache")
+			return nil
+		},
+	}
+
+	err := app.Run([]string{"", "--no-cache"})
+
+	expect(t, err, nil)
+	expect(t, cache, false)
+	expect(t, isSet, true)
 }
 
 func TestApp_CommandWithDash(t *testing.T) {
@@ -583,9 +683,7 @@ func TestApp_CommandWithNoFlagBeforeTerminator(t *testing.T) {
 
 	_ = app.Run([]string{"", "cmd", "my-arg", "--", "notAFlagAtAll"})
 
-	expect(t, args.Get(0), "my-arg")
-	expect(t, args.Get(1), "--")
-	expect(t, args.Get(2), "notAFlagAtAll")
+	expect(t, args.Slice(), []string{"my-arg", "notAFlagAtAll"})
 }
 
 func TestApp_VisibleCommands(t *testing.T) {
@@ -610,166 +708,1122 @@ func TestApp_VisibleCommands(t *testing.T) {
 		app.Commands[0],
 		app.Commands[2], // help
 	}
-	actual := app.VisibleCommands()
-	expect(t, len(expected), len(actual))
-	for i, actualCommand := range actual {
-		expectedCommand := expected[i]
-
-		if expectedCommand.Action != nil {
-			// comparing func addresses is OK!
-			expect(t, fmt.Sprintf("%p", expectedCommand.Action), fmt.Sprintf("%p", actualCommand.Action))
-		}
+	actual := app.VisibleCommands()
+	expect(t, len(expected), len(actual))
+	for i, actualCommand := range actual {
+		expectedCommand := expected[i]
+
+		if expectedCommand.Action != nil {
+			// comparing func addresses is OK!
+			expect(t, fmt.Sprintf("%p", expectedCommand.Action), fmt.Sprintf("%p", actualCommand.Action))
+		}
+
+		func() {
+			// nil out funcs, as they cannot be compared
+			// (https://github.com/golang/go/issues/8554)
+			expectedAction := expectedCommand.Action
+			actualAction := actualCommand.Action
+			defer func() {
+				expectedCommand.Action = expectedAction
+				actualCommand.Action = actualAction
+			}()
+			expectedCommand.Action = nil
+			actualCommand.Action = nil
+
+			if !reflect.DeepEqual(expectedCommand, actualCommand) {
+				t.Errorf("expected\n%#v\n!=\n%#v", expectedCommand, actualCommand)
+			}
+		}()
+	}
+}
+
+func TestApp_UseShortOptionHandling(t *testing.T) {
+	var one, two bool
+	var name string
+	expected := "expectedName"
+
+	app := newTestApp()
+	app.UseShortOptionHandling = true
+	app.Flags = []Flag{
+		&BoolFlag{Name: "one", Aliases: []string{"o"}},
+		&BoolFlag{Name: "two", Aliases: []string{"t"}},
+		&StringFlag{Name: "name", Aliases: []string{"n"}},
+	}
+	app.Action = func(c *Context) error {
+		one = c.Bool("one")
+		two = c.Bool("two")
+		name = c.String("name")
+		return nil
+	}
+
+	_ = app.Run([]string{"", "-on", expected})
+	expect(t, one, true)
+	expect(t, two, false)
+	expect(t, name, expected)
+}
+
+func TestApp_UseShortOptionHandling_missing_value(t *testing.T) {
+	app := newTestApp()
+	app.UseShortOptionHandling = true
+	app.Flags = []Flag{
+		&StringFlag{Name: "name", Aliases: []string{"n"}},
+	}
+
+	err := app.Run([]string{"", "-n"})
+	expect(t, err, errors.New("flag needs an argument: -n"))
+}
+
+func TestApp_UseShortOptionHandlingCommand(t *testing.T) {
+	var one, two bool
+	var name string
+	expected := "expectedName"
+
+	app := newTestApp()
+	app.UseShortOptionHandling = true
+	command := &Command{
+		Name: "cmd",
+		Flags: []Flag{
+			&BoolFlag{Name: "one", Aliases: []string{"o"}},
+			&BoolFlag{Name: "two", Aliases: []string{"t"}},
+			&StringFlag{Name: "name", Aliases: []string{"n"}},
+		},
+		Action: func(c *Context) error {
+			one = c.Bool("one")
+			two = c.Bool("two")
+			name = c.String("name")
+			return nil
+		},
+	}
+	app.Commands = []*Command{command}
+
+	_ = app.Run([]string{"", "cmd", "-on", expected})
+	expect(t, one, true)
+	expect(t, two, false)
+	expect(t, name, expected)
+}
+
+func TestApp_UseShortOptionHandlingCommand_missing_value(t *testing.T) {
+	app := newTestApp()
+	app.UseShortOptionHandling = true
+	command := &Command{
+		Name: "cmd",
+		Flags: []Flag{
+			&StringFlag{Name: "name", Aliases: []string{"n"}},
+		},
+	}
+	app.Commands = []*Command{command}
+
+	err := app.Run([]string{"", "cmd", "-n"})
+	expect(t, err, errors.New("flag needs an argument: -n"))
+}
+
+func TestApp_UseShortOptionHandlingSubCommand(t *testing.T) {
+	var one, two bool
+	var name string
+	expected := "expectedName"
+
+	app := newTestApp()
+	app.UseShortOptionHandling = true
+	command := &Command{
+		Name: "cmd",
+	}
+	subCommand := &Command{
+		Name: "sub",
+		Flags: []Flag{
+			&BoolFlag{Name: "one", Aliases: []string{"o"}},
+			&BoolFlag{Name: "two", Aliases: []string{"t"}},
+			&StringFlag{Name: "name", Aliases: []string{"n"}},
+		},
+		Action: func(c *Context) error {
+			one = c.Bool("one")
+			two = c.Bool("two")
+			name = c.String("name")
+			return nil
+		},
+	}
+	command.Subcommands = []*Command{subCommand}
+	app.Commands = []*Command{command}
+
+	err := app.Run([]string{"", "cmd", "sub", "-on", expected})
+	expect(t, err, nil)
+	expect(t, one, true)
+	expect(t, two, false)
+	expect(t, name, expected)
+}
+
+func TestApp_UseShortOptionHandlingSubCommand_missing_value(t *testing.T) {
+	app := newTestApp()
+	app.UseShortOptionHandling = true
+	command := &Command{
+		Name: "cmd",
+	}
+	subCommand := &Command{
+		Name: "sub",
+		Flags: []Flag{
+			&StringFlag{Name: "name", Aliases: []string{"n"}},
+		},
+	}
+	command.Subcommands = []*Command{subCommand}
+	app.Commands = []*Command{command}
+
+	err := app.Run([]string{"", "cmd", "sub", "-n"})
+	expect(t, err, errors.New("flag needs an argument: -n"))
+}
+
+func TestApp_CountFlag(t *testing.T) {
+	var verbosity, quiet int
+
+	app := newTestApp()
+	app.UseShortOptionHandling = true
+	app.Flags = []Flag{
+		&CountFlag{Name: "verbose", Aliases: []string{"v"}},
+		&CountFlag{Name: "quiet", Aliases: []string{"q"}, Value: 1},
+	}
+	app.Action = func(c *Context) error {
+		verbosity = c.Count("verbose")
+		quiet = c.Count("q")
+		return nil
+	}
+
+	err := app.Run([]string{"", "-vvv", "--quiet=0"})
+	expect(t, err, nil)
+	expect(t, verbosity, 3)
+	expect(t, quiet, 0)
+}
+
+func TestApp_CountFlagFromEnv(t *testing.T) {
+	os.Clearenv()
+	_ = os.Setenv("APP_VERBOSE", "2")
+
+	var verbosity int
+	app := newTestApp()
+	app.CountVarP(&verbosity, "verbose", "v", 0, "verbosity level", "APP_VERBOSE")
+	app.Action = func(c *Context) error { return nil }
+
+	err := app.Run([]string{""})
+	expect(t, err, nil)
+	expect(t, verbosity, 2)
+
+	err = app.Run([]string{"", "-v"})
+	expect(t, err, nil)
+	expect(t, verbosity, 1)
+
+	app = newTestApp()
+	app.UseShortOptionHandling = true
+	app.CountVarP(&verbosity, "verbose", "v", 0, "verbosity level", "APP_VERBOSE")
+	app.Action = func(c *Context) error { return nil }
+	err = app.Run([]string{"", "-vvv"})
+	expect(t, err, nil)
+	expect(t, verbosity, 3)
+}
+
+func TestApp_ByteSizeFlag(t *testing.T) {
+	var buffer uint64
+
+	app := newTestApp()
+	cache := app.ByteSizeP("cache", "c", 64<<20, "cache size", "")
+	app.ByteSizeVar(&buffer, "buffer", 4096, "buffer size", "")
+	app.Action = func(c *Context) error {
+		expect(t, c.ByteSize("c"), uint64(1500000000))
+		return nil
+	}
+
+	err := app.Run([]string{"", "-c", "1.5GB"})
+	expect(t, err, nil)
+	expect(t, *cache, uint64(1500000000))
+	expect(t, buffer, uint64(4096))
+}
+
+func TestApp_NetworkFlags(t *testing.T) {
+	app := newTestApp()
+	app.Flags = []Flag{
+		&HostPortFlag{Name: "listen", Value: ":8080"},
+		&IPNetSliceFlag{Name: "allow-cidr"},
+		&URLFlag{Name: "endpoint", Schemes: []string{"https"}},
+	}
+	app.Action = func(c *Context) error {
+		expect(t, c.HostPort("listen"), ":8080")
+		expect(t, c.IPNetSlice("allow-cidr")[1].String(), "fd00::/8")
+		expect(t, c.URL("endpoint").Host, "example.com")
+		return nil
+	}
+
+	err := app.Run([]string{"", "--allow-cidr", "10.0.0.0/8", "--allow-cidr", "fd00::/8", "--endpoint", "https://example.com"})
+	expect(t, err, nil)
+
+	err = app.Run([]string{"", "--endpoint", "http://example.com"})
+	expect(t, err, errors.New("invalid value \"http://example.com\" for flag --endpoint (from command line): invalid URL \"http://example.com\": scheme must be one of https"))
+}
+
+func TestApp_Bind(t *testing.T) {
+	os.Clearenv()
+	_ = os.Setenv("APP_NAME", "gopher")
+
+	var cfg struct {
+		Name    string `env:"APP_NAME"`
+		Verbose bool   `cli:"verbose,v"`
+		Tags    []string
+		DB      struct {
+			Host string `default:"localhost"`
+			Port int    `default:"5432"`
+		}
+	}
+
+	app := newTestApp()
+	app.Action = func(c *Context) error { return nil }
+	err := app.Bind(&cfg)
+	expect(t, err, nil)
+
+	err = app.Run([]string{"", "-v", "--tags", "a", "--tags", "b", "--db-host", "db.local"})
+	expect(t, err, nil)
+	expect(t, cfg.Name, "gopher")
+	expect(t, cfg.Verbose, true)
+	expect(t, cfg.Tags, []string{"a", "b"})
+	expect(t, cfg.DB.Host, "db.local")
+	expect(t, cfg.DB.Port, 5432)
+}
+
+func TestApp_BindEnvSliceAndMap(t *testing.T) {
+	os.Clearenv()
+	_ = os.Setenv("APP_TAGS", "a,b")
+	_ = os.Setenv("APP_PORTS", "80,443")
+	_ = os.Setenv("APP_LABELS", "env=prod")
+
+	var cfg struct {
+		Tags   []string          `env:"APP_TAGS" default:"x"`
+		Ports  []int             `env:"APP_PORTS"`
+		Labels map[string]string `env:"APP_LABELS" default:"env=dev"`
+	}
+
+	app := newTestApp()
+	app.Action = func(c *Context) error { return nil }
+	expect(t, app.Bind(&cfg), nil)
+
+	expect(t, app.Run([]string{""}), nil)
+	expect(t, cfg.Tags, []string{"a", "b"})
+	expect(t, cfg.Ports, []int{80, 443})
+	expect(t, cfg.Labels, map[string]string{"env": "prod"})
+}
+
+func TestApp_FlagValidator(t *testing.T) {
+	os.Clearenv()
+	_ = os.Setenv("APP_PORT", "80")
+
+	var beforeRun bool
+	var validated []interface{}
+	app := newTestApp()
+	app.Flags = []Flag{
+		&IntFlag{Name: "port", EnvVars: []string{"APP_PORT"}, Validator: Min(1024)},
+		&StringSliceFlag{Name: "tag", Validator: MaxItems(2)},
+		&StringFlag{Name: "name", Validator: func(value interface{}) error {
+			validated = append(validated, value)
+			return nil
+		}},
+	}
+	app.Before = func(c *Context) error {
+		beforeRun = true
+		return nil
+	}
+	app.Action = func(c *Context) error { return nil }
+
+	err := app.Run([]string{""})
+	expect(t, err.Error(), "invalid value \"80\" for flag --port (from $APP_PORT): must be at least 1024")
+	expect(t, errors.Unwrap(err), errors.New("must be at least 1024"))
+	expect(t, beforeRun, false)
+	expect(t, len(validated), 0)
+
+	err = app.Run([]string{"", "--port", "8080", "--tag", "a,b,c"})
+	expect(t, err.Error(), "invalid value \"[a,b,c]\" for flag --tag (from command line): must have at most 2 items")
+
+	var usageErr error
+	app.OnUsageError = func(c *Context, err error, isSubcommand bool) error {
+		usageErr = err
+		return err
+	}
+	err = app.Run([]string{"", "--port", "8080", "--name", "gopher"})
+	expect(t, err, nil)
+	expect(t, usageErr, nil)
+	expect(t, validated, []interface{}{"gopher"})
+	expect(t, beforeRun, true)
+
+	err = app.Run([]string{"", "--port", "22"})
+	expect(t, usageErr, err)
+}
+
+func TestApp_CommandFlagValidator(t *testing.T) {
+	app := newTestApp()
+	app.Commands = []*Command{
+		{
+			Name:   "serve",
+			Flags:  []Flag{&DurationFlag{Name: "timeout", Validator: Max(time.Minute)}},
+			Action: func(c *Context) error { return nil },
+		},
+	}
+
+	err := app.Run([]string{"", "serve", "--timeout", "2m"})
+	expect(t, err.Error(), "invalid value \"2m0s\" for flag --timeout (from command line): must be at most 1m0s")
+}
+
+func TestApp_FlagGroups(t *testing.T) {
+	var actionRun bool
+	var buf bytes.Buffer
+	app := newTestApp()
+	app.Writer = &buf
+	app.Flags = []Flag{
+		&StringFlag{Name: "token"},
+		&StringFlag{Name: "token-file"},
+	}
+	app.FlagGroups = []FlagGroup{MutuallyExclusive("token", "token-file")}
+	app.Action = func(c *Context) error {
+		actionRun = true
+		return nil
+	}
+
+	err := app.Run([]string{"", "--token", "abc", "--token-file", "f"})
+	expect(t, err.Error(), "Flags \"token, token-file\" are mutually exclusive")
+	expect(t, actionRun, false)
+	if !strings.Contains(buf.String(), "(--token | --token-file)") {
+		t.Errorf("expected help to show the flag group; got: %q", buf.String())
+	}
+
+	err = app.Run([]string{"", "--token", "abc"})
+	expect(t, err, nil)
+	expect(t, actionRun, true)
+}
+
+func TestApp_CommandFlagGroups(t *testing.T) {
+	app := newTestApp()
+	app.Commands = []*Command{
+		{
+			Name: "serve",
+			Flags: []Flag{
+				&StringFlag{Name: "cert"},
+				&StringFlag{Name: "key"},
+			},
+			FlagGroups: []FlagGroup{RequiredTogether("cert", "key")},
+			Action:     func(c *Context) error { return nil },
+		},
+		{
+			Name: "list",
+			Flags: []Flag{
+				&BoolFlag{Name: "all"},
+				&StringFlag{Name: "name"},
+			},
+			FlagGroups: []FlagGroup{ExactlyOne("all", "name")},
+			Subcommands: []*Command{
+				{Name: "users", Action: func(c *Context) error { return nil }},
+			},
+		},
+	}
+
+	err := app.Run([]string{"", "serve", "--cert", "c"})
+	expect(t, err.Error(), "Flags \"cert, key\" must be set together, \"key\" not set")
+
+	err = app.Run([]string{"", "serve", "--cert", "c", "--key", "k"})
+	expect(t, err, nil)
+
+	err = app.Run([]string{"", "list", "users"})
+	expect(t, err.Error(), "One of flags \"all, name\" must be set")
+
+	err = app.Run([]string{"", "list", "--all", "users"})
+	expect(t, err, nil)
+}
+
+func TestApp_DeprecatedFlag(t *testing.T) {
+	var errBuf bytes.Buffer
+	var host string
+	var verbose bool
+	app := newTestApp()
+	app.ErrWriter = &errBuf
+	app.Flags = []Flag{
+		&StringFlag{Name: "host", Aliases: []string{"H"}, Destination: &host},
+		&StringFlag{Name: "server", ReplacedBy: "host"},
+		&BoolFlag{Name: "verbose", Destination: &verbose},
+		&BoolFlag{Name: "debug", Deprecated: "will be removed in v3", ReplacedBy: "verbose"},
+	}
+	app.Action = func(c *Context) error {
+		expect(t, c.String("H"), "example.com")
+		expect(t, c.IsSet("host"), true)
+		return nil
+	}
+
+	err := app.Run([]string{"", "--server", "example.com", "--debug"})
+	expect(t, err, nil)
+	expect(t, host, "example.com")
+	expect(t, verbose, true)
+	expect(t, errBuf.String(), "Warning: Flag --server is deprecated: use --host instead\n"+
+		"Warning: Flag --debug is deprecated: will be removed in v3, use --verbose instead\n")
+
+	// the replacement wins when both are set
+	errBuf.Reset()
+	err = app.Run([]string{"", "--server", "old.example.com", "--host", "example.com"})
+	expect(t, err, nil)
+	expect(t, host, "example.com")
+	expect(t, errBuf.String(), "Warning: Flag --server is deprecated: use --host instead\n")
+
+	errBuf.Reset()
+	app.StrictDeprecations = true
+	err = app.Run([]string{"", "--debug"})
+	expect(t, err.Error(), "Flag --debug is deprecated: will be removed in v3, use --verbose instead")
+	expect(t, errBuf.String(), "")
+}
+
+func TestApp_DeprecatedFlagReplacedByParentFlag(t *testing.T) {
+	var errBuf bytes.Buffer
+	var host string
+	app := newTestApp()
+	app.ErrWriter = &errBuf
+	app.Flags = []Flag{
+		&StringFlag{Name: "host", Aliases: []string{"H"}, Destination: &host},
+	}
+	app.Commands = []*Command{
+		{
+			Name:  "ping",
+			Flags: []Flag{&StringFlag{Name: "server", ReplacedBy: "host"}},
+			Action: func(c *Context) error {
+				expect(t, c.String("H"), "example.com")
+				expect(t, c.IsSet("host"), true)
+				return nil
+			},
+		},
+	}
+
+	err := app.Run([]string{"", "ping", "--server", "example.com"})
+	expect(t, err, nil)
+	expect(t, host, "example.com")
+	expect(t, errBuf.String(), "Warning: Flag --server is deprecated: use --host instead\n")
+
+	// the replacement set on the parent wins
+	err = app.Run([]string{"", "--host", "example.com", "ping", "--server", "old.example.com"})
+	expect(t, err, nil)
+	expect(t, host, "example.com")
+}
+
+func TestApp_DeprecatedCommand(t *testing.T) {
+	var errBuf bytes.Buffer
+	var actionRun bool
+	app := newTestApp()
+	app.ErrWriter = &errBuf
+	app.Commands = []*Command{
+		{
+			Name:       "rm",
+			ReplacedBy: "remove",
+			Action: func(c *Context) error {
+				actionRun = true
+				return nil
+			},
+		},
+		{
+			Name:   "remove",
+			Action: func(c *Context) error { return nil },
+		},
+	}
+
+	err := app.Run([]string{"", "rm"})
+	expect(t, err, nil)
+	expect(t, actionRun, true)
+	expect(t, errBuf.String(), "Warning: Command rm is deprecated: use remove instead\n")
+	expect(t, len(app.VisibleCommands()), 2) // remove and help
+
+	actionRun = false
+	app.StrictDeprecations = true
+	err = app.Run([]string{"", "rm"})
+	expect(t, err.Error(), "Command rm is deprecated: use remove instead")
+	expect(t, actionRun, false)
+}
+
+func TestApp_PersistentFlags(t *testing.T) {
+	var verbose bool
+	var token, name string
+	var tokenIsSet bool
+	app := newTestApp()
+	app.Flags = []Flag{
+		&BoolFlag{Name: "verbose", Aliases: []string{"v"}, Persistent: true},
+		&StringFlag{Name: "token", Required: true, Persistent: true},
+		&StringFlag{Name: "region"},
+	}
+	app.Commands = []*Command{
+		{
+			Name: "remote",
+			Subcommands: []*Command{
+				{
+					Name:  "add",
+					Flags: []Flag{&StringFlag{Name: "name"}},
+					Action: func(c *Context) error {
+						verbose = c.Bool("verbose")
+						token = c.String("token")
+						tokenIsSet = c.IsSet("token")
+						name = c.String("name")
+						return nil
+					},
+				},
+			},
+		},
+	}
+
+	err := app.Run([]string{"", "remote", "add", "-v", "--name", "origin", "--token", "secret"})
+	expect(t, err, nil)
+	expect(t, verbose, true)
+	expect(t, token, "secret")
+	expect(t, tokenIsSet, true)
+	expect(t, name, "origin")
+
+	verbose, token = false, ""
+	err = app.Run([]string{"", "--token", "secret", "remote", "--verbose", "add"})
+	expect(t, err, nil)
+	expect(t, verbose, true)
+	expect(t, token, "secret")
+
+	err = app.Run([]string{"", "remote", "add", "--name", "origin"})
+	expect(t, err.Error(), "Required flag \"token\" not set")
+
+	err = app.Run([]string{"", "remote", "add", "--token", "secret", "--region", "eu"})
+	expect(t, err, &UnknownFlagError{Name: "--region"})
+}
+
+func TestApp_PersistentSliceFlags(t *testing.T) {
+	var ips, nets, hosts, urls []string
+	app := newTestApp()
+	app.Flags = []Flag{
+		&IPSliceFlag{Name: "dns", Persistent: true},
+		&IPNetSliceFlag{Name: "allow", Persistent: true},
+		&HostPortSliceFlag{Name: "peer", Persistent: true},
+		&URLSliceFlag{Name: "mirror", Persistent: true},
+	}
+	app.Action = func(c *Context) error { return nil }
+	app.Commands = []*Command{
+		{
+			Name: "sub",
+			Action: func(c *Context) error {
+				for _, ip := range c.IPSlice("dns") {
+					ips = append(ips, ip.String())
+				}
+				for _, n := range c.IPNetSlice("allow") {
+					nets = append(nets, n.String())
+				}
+				hosts = c.HostPortSlice("peer")
+				for _, u := range c.URLSlice("mirror") {
+					urls = append(urls, u.String())
+				}
+				return nil
+			},
+		},
+	}
+
+	err := app.Run([]string{"", "sub", "--dns", "1.1.1.1", "--allow", "10.0.0.0/8", "--peer", "db:5432", "--mirror", "https://example.com"})
+	expect(t, err, nil)
+	expect(t, ips, []string{"1.1.1.1"})
+	expect(t, nets, []string{"10.0.0.0/8"})
+	expect(t, hosts, []string{"db:5432"})
+	expect(t, urls, []string{"https://example.com"})
+}
+
+func TestApp_PersistentFlagsValueFromFile(t *testing.T) {
+	dir, err := ioutil.TempDir("", "cli")
+	expect(t, err, nil)
+	defer os.RemoveAll(dir)
+	_ = ioutil.WriteFile(dir+"/v.txt", []byte("hello\n"), 0644)
+
+	var s string
+	app := newTestApp()
+	app.Flags = []Flag{&StringFlag{Name: "s", Persistent: true, ValueFromFile: true}}
+	app.Action = func(c *Context) error { return nil }
+	app.Commands = []*Command{
+		{
+			Name: "sub",
+			Action: func(c *Context) error {
+				s = c.String("s")
+				return nil
+			},
+		},
+	}
+
+	for _, args := range [][]string{
+		{"", "--s", "@" + dir + "/v.txt", "sub"},
+		{"", "sub", "--s", "@" + dir + "/v.txt"},
+		{"", "sub", "--s=@" + dir + "/v.txt"},
+	} {
+		s = ""
+		expect(t, app.Run(args), nil)
+		expect(t, s, "hello")
+	}
+}
+
+func TestApp_AllowAbbreviations(t *testing.T) {
+	var ran, format string
+	var args []string
+	app := newTestApp()
+	app.AllowAbbreviations = true
+	app.Action = func(c *Context) error {
+		ran = "app"
+		args = c.Args().Slice()
+		return nil
+	}
+	app.Commands = []*Command{
+		{
+			Name: "deployments",
+			Subcommands: []*Command{
+				{
+					Name: "list",
+					Flags: []Flag{
+						&StringFlag{Name: "format"},
+						&BoolFlag{Name: "force"},
+					},
+					Action: func(c *Context) error {
+						ran = "list"
+						format = c.String("format")
+						return nil
+					},
+				},
+				{
+					Name:   "logs",
+					Action: func(c *Context) error { ran = "logs"; return nil },
+				},
+			},
+		},
+		{
+			Name:   "debug",
+			Hidden: true,
+			Action: func(c *Context) error { ran = "debug"; return nil },
+		},
+	}
+
+	err := app.Run([]string{"", "dep", "li", "--form", "json"})
+	expect(t, err, nil)
+	expect(t, ran, "list")
+	expect(t, format, "json")
+
+	err = app.Run([]string{"", "dep", "lo"})
+	expect(t, err, nil)
+	expect(t, ran, "logs")
+
+	err = app.Run([]string{"", "dep", "l"})
+	expect(t, err, errors.New("ambiguous command \"l\", could be one of: list, logs"))
+
+	err = app.Run([]string{"", "dep", "list", "--fo"})
+	expect(t, err, errors.New("ambiguous flag --fo, could be one of: --force, --format"))
+
+	err = app.Run([]string{"", "deb"})
+	expect(t, err, nil)
+	expect(t, ran, "app")
+	expect(t, args, []string{"deb"})
+
+	app.AllowAbbreviations = false
+	err = app.Run([]string{"", "dep"})
+	expect(t, err, nil)
+	expect(t, ran, "app")
+	expect(t, args, []string{"dep"})
+}
+
+func TestApp_Suggestions(t *testing.T) {
+	app := newTestApp()
+	app.Flags = []Flag{&BoolFlag{Name: "verbose", Aliases: []string{"v"}}}
+	app.Commands = []*Command{
+		{Name: "status", Action: func(c *Context) error { return nil }},
+		{Name: "start", Action: func(c *Context) error { return nil }},
+		{Name: "secret", Hidden: true, Action: func(c *Context) error { return nil }},
+	}
+
+	err := app.Run([]string{"", "statsu"})
+	expect(t, err, &UnknownCommandError{Name: "statsu", Suggestions: []string{"status"}})
+	expect(t, err.Error(), "Unknown command \"statsu\". Did you mean \"status\"?")
+
+	err = app.Run([]string{"", "sta"})
+	expect(t, err.Error(), "Unknown command \"sta\". Did you mean one of \"start\", \"status\"?")
+
+	err = app.Run([]string{"", "secrte"})
+	expect(t, err.Error(), "Unknown command \"secrte\"")
+
+	err = app.Run([]string{"", "--verbos"})
+	expect(t, err.Error(), "flag provided but not defined: --verbos. Did you mean \"--verbose\"?")
+
+	app.SuggestionsMinDistance = 1
+	err = app.Run([]string{"", "stauts"})
+	expect(t, err.Error(), "Unknown command \"stauts\"")
+
+	app.SuggestionsMinDistance = 0
+	app.DisableSuggestions = true
+	err = app.Run([]string{"", "statsu"})
+	expect(t, err.Error(), "Unknown command \"statsu\"")
+}
+
+func TestApp_SuggestionsHandlers(t *testing.T) {
+	var notFound []string
+	var usageErr error
+	app := newTestApp()
+	app.Commands = []*Command{
+		{Name: "status", Action: func(c *Context) error { return nil }},
+	}
+	app.CommandNotFound = func(c *Context, command string) {
+		notFound = c.CommandSuggestions(command)
+	}
+
+	err := app.Run([]string{"", "statsu"})
+	expect(t, err, nil)
+	expect(t, notFound, []string{"status"})
+
+	app.CommandNotFound = nil
+	app.OnUsageError = func(c *Context, err error, isSubcommand bool) error {
+		usageErr = err
+		return err
+	}
+
+	_ = app.Run([]string{"", "--stat"})
+	expect(t, usageErr, &UnknownFlagError{Name: "--stat"})
+
+	_ = app.Run([]string{"", "statsu"})
+	expect(t, usageErr, &UnknownCommandError{Name: "statsu", Suggestions: []string{"status"}})
+}
+
+func TestApp_ErrHelpShown(t *testing.T) {
+	app := newTestApp()
+	app.Version = "1.0.0"
+	app.Commands = []*Command{
+		{Name: "deploy", Action: func(c *Context) error { return nil }},
+	}
+
+	for _, args := range [][]string{
+		{"", "--help"},
+		{"", "--version"},
+		{"", "help"},
+		{"", "help", "deploy"},
+		{"", "deploy", "--help"},
+	} {
+		lastExitCode = -1
+		err := app.Run(args)
+		if !errors.Is(err, ErrHelpShown) {
+			t.Errorf("expected ErrHelpShown for %v, got: %v", args, err)
+		}
+		expect(t, lastExitCode, -1)
+	}
+}
+
+func TestApp_ExitOnHelp(t *testing.T) {
+	app := newTestApp()
+	app.ExitOnHelp = true
+	app.Commands = []*Command{
+		{Name: "deploy", Action: func(c *Context) error { return nil }},
+	}
+
+	for _, args := range [][]string{{"", "--help"}, {"", "deploy", "-h"}} {
+		lastExitCode = -1
+		_ = app.Run(args)
+		expect(t, lastExitCode, 0)
+	}
+}
+
+func TestApp_FlagActions(t *testing.T) {
+	os.Clearenv()
+	_ = os.Setenv("APP_LEVEL", "debug")
+
+	var called []string
+	action := func(c *Context, value interface{}) error {
+		called = append(called, fmt.Sprintf("%v", value))
+		return nil
+	}
+	app := newTestApp()
+	app.Flags = []Flag{
+		&StringFlag{Name: "level", EnvVars: []string{"APP_LEVEL"}, Action: action},
+		&IntFlag{Name: "port", Action: action},
+		&StringSliceFlag{Name: "tag", Action: action},
+		&BoolFlag{Name: "verbose", Action: action},
+	}
+	app.Before = func(c *Context) error {
+		called = append(called, "before")
+		return nil
+	}
+	app.Action = func(c *Context) error {
+		called = append(called, "action")
+		return nil
+	}
+
+	err := app.Run([]string{"", "--tag", "a", "--port", "80", "--tag", "b"})
+	expect(t, err, nil)
+	expect(t, called, []string{"debug", "80", "[a b]", "before", "action"})
+}
+
+func TestApp_FlagActionError(t *testing.T) {
+	var exitCode int
+	var beforeRun bool
+	app := newTestApp()
+	app.ExitErrHandler = func(c *Context, err error) {
+		if ec, ok := err.(ExitCoder); ok {
+			exitCode = ec.ExitCode()
+		}
+	}
+	app.Commands = []*Command{
+		{
+			Name: "serve",
+			Flags: []Flag{
+				&PathFlag{Name: "chdir", Action: func(c *Context, value interface{}) error {
+					return Exit("cannot change directory to "+value.(string), 3)
+				}},
+			},
+			Before: func(c *Context) error {
+				beforeRun = true
+				return nil
+			},
+			Action: func(c *Context) error { return nil },
+		},
+	}
+
+	err := app.Run([]string{"", "serve", "--chdir", "/nowhere"})
+	expect(t, err.Error(), "cannot change directory to /nowhere")
+	expect(t, exitCode, 3)
+	expect(t, beforeRun, false)
+
+	exitCode = 0
+	err = app.Run([]string{"", "serve"})
+	expect(t, err, nil)
+	expect(t, exitCode, 0)
+	expect(t, beforeRun, true)
+}
+
+func TestApp_Arguments(t *testing.T) {
+	var src string
+	var count int
+	var ratio float64
+	var files []string
+	app := newTestApp()
+	app.Arguments = []Argument{
+		{Name: "src", Type: PathArg},
+		{Name: "count", Type: IntArg},
+		{Name: "ratio", Type: Float64Arg, Optional: true, Value: "0.5"},
+		{Name: "files", Variadic: true, Optional: true},
+	}
+	app.Action = func(c *Context) error {
+		src = c.Arg("src")
+		count = c.ArgInt("count")
+		ratio = c.ArgFloat64("ratio")
+		files = c.ArgSlice("files")
+		return nil
+	}
+
+	err := app.Run([]string{"", "a.txt", "3", "1.5", "b", "c"})
+	expect(t, err, nil)
+	expect(t, src, "a.txt")
+	expect(t, count, 3)
+	expect(t, ratio, 1.5)
+	expect(t, files, []string{"b", "c"})
+
+	err = app.Run([]string{"", "a.txt", "3"})
+	expect(t, err, nil)
+	expect(t, ratio, 0.5)
+	expect(t, files, []string{})
+
+	var usageErr error
+	app.OnUsageError = func(c *Context, err error, isSubcommand bool) error {
+		usageErr = err
+		return err
+	}
+	err = app.Run([]string{"", "a.txt"})
+	expect(t, err.Error(), "Required argument \"count\" not set")
+	expect(t, usageErr, err)
+}
+
+func TestApp_CommandArguments(t *testing.T) {
+	var name string
+	var buf bytes.Buffer
+	app := newTestApp()
+	app.Writer = &buf
+	app.Commands = []*Command{
+		{
+			Name: "greet",
+			Arguments: []Argument{
+				{Name: "name", Usage: "who to greet"},
+			},
+			Action: func(c *Context) error {
+				name = c.Arg("name")
+				return nil
+			},
+		},
+	}
 
-		func() {
-			// nil out funcs, as they cannot be compared
-			// (https://github.com/golang/go/issues/8554)
-			expectedAction := expectedCommand.Action
-			actualAction := actualCommand.Action
-			defer func() {
-				expectedCommand.Action = expectedAction
-				actualCommand.Action = actualAction
-			}()
-			expectedCommand.Action = nil
-			actualCommand.Action = nil
+	err := app.Run([]string{"", "greet", "gopher"})
+	expect(t, err, nil)
+	expect(t, name, "gopher")
 
-			if !reflect.DeepEqual(expectedCommand, actualCommand) {
-				t.Errorf("expected\n%#v\n!=\n%#v", expectedCommand, actualCommand)
-			}
-		}()
+	err = app.Run([]string{"", "greet", "gopher", "again"})
+	expect(t, err.Error(), "Unexpected argument \"again\"")
+	if !strings.Contains(buf.String(), "Incorrect Usage: Unexpected argument \"again\"") {
+		t.Errorf("expected the usage error in the output; got: %q", buf.String())
 	}
 }
 
-func TestApp_UseShortOptionHandling(t *testing.T) {
-	var one, two bool
-	var name string
-	expected := "expectedName"
+func TestApp_DebugFlags(t *testing.T) {
+	os.Clearenv()
+	_ = os.Setenv("APP_PORT", "8080")
 
+	var actionRun bool
+	var buf bytes.Buffer
 	app := newTestApp()
-	app.UseShortOptionHandling = true
+	app.Writer = &buf
 	app.Flags = []Flag{
-		&BoolFlag{Name: "one", Aliases: []string{"o"}},
-		&BoolFlag{Name: "two", Aliases: []string{"t"}},
-		&StringFlag{Name: "name", Aliases: []string{"n"}},
+		&IntFlag{Name: "port", EnvVars: []string{"APP_PORT"}},
+		&StringFlag{Name: "name", Value: "gopher"},
 	}
-	app.Action = func(c *Context) error {
-		one = c.Bool("one")
-		two = c.Bool("two")
-		name = c.String("name")
-		return nil
+	app.Commands = []*Command{
+		{
+			Name:  "serve",
+			Flags: []Flag{&BoolFlag{Name: "tls", Aliases: []string{"s"}}},
+			Action: func(c *Context) error {
+				actionRun = true
+				return nil
+			},
+		},
 	}
 
-	_ = app.Run([]string{"", "-on", expected})
-	expect(t, one, true)
-	expect(t, two, false)
-	expect(t, name, expected)
+	err := app.Run([]string{"", "serve", "-s", "--debug-flags"})
+	expect(t, err, nil)
+	expect(t, actionRun, false)
+	expect(t, buf.String(), `FLAG    VALUE   SOURCE
+--port  8080    $APP_PORT
+--name  gopher  default
+--tls   true    command line
+`)
+
+	err = app.Run([]string{"", "serve"})
+	expect(t, err, nil)
+	expect(t, actionRun, true)
 }
 
-func TestApp_UseShortOptionHandling_missing_value(t *testing.T) {
+func TestApp_EnvVarParseErrorSource(t *testing.T) {
+	os.Clearenv()
+	_ = os.Setenv("APP_PORT", "http")
+
 	app := newTestApp()
-	app.UseShortOptionHandling = true
 	app.Flags = []Flag{
-		&StringFlag{Name: "name", Aliases: []string{"n"}},
+		&IntFlag{Name: "port", EnvVars: []string{"APP_HTTP_PORT", "APP_PORT"}},
 	}
+	app.Action = func(c *Context) error { return nil }
 
-	err := app.Run([]string{"", "-n"})
-	expect(t, err, errors.New("flag needs an argument: -n"))
+	err := app.Run([]string{""})
+	expect(t, err.Error(), "could not parse \"http\" as int value for flag port: strconv.ParseInt: parsing \"http\": invalid syntax (from $APP_PORT)")
 }
 
-func TestApp_UseShortOptionHandlingCommand(t *testing.T) {
-	var one, two bool
-	var name string
-	expected := "expectedName"
+func TestApp_EnvPrefix(t *testing.T) {
+	os.Clearenv()
+	_ = os.Setenv("MYAPP_DB_HOST", "db.local")
+	_ = os.Setenv("MYAPP_VERBOSE", "true")
+	_ = os.Setenv("MYAPP_SERVE_PORT", "8080")
+	_ = os.Setenv("WEB_WORKERS", "4")
+	_ = os.Setenv("TIMEOUT", "10")
 
+	var host, port, workers, timeout string
+	var verbose bool
 	app := newTestApp()
-	app.UseShortOptionHandling = true
-	command := &Command{
-		Name: "cmd",
-		Flags: []Flag{
-			&BoolFlag{Name: "one", Aliases: []string{"o"}},
-			&BoolFlag{Name: "two", Aliases: []string{"t"}},
-			&StringFlag{Name: "name", Aliases: []string{"n"}},
+	app.EnvPrefix = "MYAPP"
+	app.Flags = []Flag{
+		&StringFlag{Name: "db-host"},
+		&BoolFlag{Name: "verbose", NoAutoEnv: true},
+		&StringFlag{Name: "timeout", EnvVars: []string{"TIMEOUT"}},
+	}
+	app.Commands = []*Command{
+		{
+			Name:  "serve",
+			Flags: []Flag{&StringFlag{Name: "port"}},
+			Action: func(c *Context) error {
+				host = c.String("db-host")
+				verbose = c.Bool("verbose")
+				timeout = c.String("timeout")
+				port = c.String("port")
+				return nil
+			},
 		},
-		Action: func(c *Context) error {
-			one = c.Bool("one")
-			two = c.Bool("two")
-			name = c.String("name")
-			return nil
+		{
+			Name:      "web",
+			EnvPrefix: "WEB",
+			Flags:     []Flag{&StringFlag{Name: "workers"}},
+			Action: func(c *Context) error {
+				workers = c.String("workers")
+				return nil
+			},
 		},
 	}
-	app.Commands = []*Command{command}
 
-	_ = app.Run([]string{"", "cmd", "-on", expected})
-	expect(t, one, true)
-	expect(t, two, false)
-	expect(t, name, expected)
+	err := app.Run([]string{"", "serve"})
+	expect(t, err, nil)
+	expect(t, host, "db.local")
+	expect(t, verbose, false)
+	expect(t, timeout, "10")
+	expect(t, port, "8080")
+
+	err = app.Run([]string{"", "web"})
+	expect(t, err, nil)
+	expect(t, workers, "4")
 }
 
-func TestApp_UseShortOptionHandlingCommand_missing_value(t *testing.T) {
+func TestApp_EnvPrefixNamer(t *testing.T) {
+	defer func() { EnvVarNamer = envVarName }()
+	EnvVarNamer = func(prefix, name string) string {
+		return strings.ToLower(prefix + "." + name)
+	}
+
+	os.Clearenv()
+	_ = os.Setenv("myapp.name", "gopher")
+
+	var name string
 	app := newTestApp()
-	app.UseShortOptionHandling = true
-	command := &Command{
-		Name: "cmd",
-		Flags: []Flag{
-			&StringFlag{Name: "name", Aliases: []string{"n"}},
-		},
+	app.EnvPrefix = "MYAPP"
+	app.Flags = []Flag{&StringFlag{Name: "name"}}
+	app.Action = func(c *Context) error {
+		name = c.String("name")
+		return nil
 	}
-	app.Commands = []*Command{command}
 
-	err := app.Run([]string{"", "cmd", "-n"})
-	expect(t, err, errors.New("flag needs an argument: -n"))
+	err := app.Run([]string{""})
+	expect(t, err, nil)
+	expect(t, name, "gopher")
 }
 
-func TestApp_UseShortOptionHandlingSubCommand(t *testing.T) {
-	var one, two bool
-	var name string
-	expected := "expectedName"
+func TestApp_ResponseFiles(t *testing.T) {
+	dir, err := ioutil.TempDir("", "cli")
+	expect(t, err, nil)
+	defer os.RemoveAll(dir)
+
+	_ = ioutil.WriteFile(dir+"/common.txt", []byte("--region 'eu west'\n"), 0644)
+	_ = ioutil.WriteFile(dir+"/args.txt", []byte("# deploy settings\n@"+dir+"/common.txt\n--name \"my app\"\n"), 0644)
+	_ = ioutil.WriteFile(dir+"/payload.json", []byte(`{"a": 1}`), 0644)
 
+	var name, region, data string
+	var args []string
 	app := newTestApp()
-	app.UseShortOptionHandling = true
-	command := &Command{
-		Name: "cmd",
+	app.ResponseFiles = true
+	app.Flags = []Flag{
+		&StringFlag{Name: "name"},
+		&StringFlag{Name: "region"},
+		&StringFlag{Name: "data", ValueFromFile: true},
 	}
-	subCommand := &Command{
-		Name: "sub",
-		Flags: []Flag{
-			&BoolFlag{Name: "one", Aliases: []string{"o"}},
-			&BoolFlag{Name: "two", Aliases: []string{"t"}},
-			&StringFlag{Name: "name", Aliases: []string{"n"}},
-		},
-		Action: func(c *Context) error {
-			one = c.Bool("one")
-			two = c.Bool("two")
-			name = c.String("name")
-			return nil
-		},
+	app.Action = func(c *Context) error {
+		name = c.String("name")
+		region = c.String("region")
+		data = c.String("data")
+		args = c.Args().Slice()
+		return nil
 	}
-	command.Subcommands = []*Command{subCommand}
-	app.Commands = []*Command{command}
 
-	err := app.Run([]string{"", "cmd", "sub", "-on", expected})
+	err = app.Run([]string{"", "@" + dir + "/args.txt", "--data", "@" + dir + "/payload.json", "--", "@literal"})
 	expect(t, err, nil)
-	expect(t, one, true)
-	expect(t, two, false)
-	expect(t, name, expected)
+	expect(t, name, "my app")
+	expect(t, region, "eu west")
+	expect(t, data, `{"a": 1}`)
+	expect(t, args, []string{"@literal"})
 }
 
-func TestApp_UseShortOptionHandlingSubCommand_missing_value(t *testing.T) {
+func TestApp_ResponseFilesRecursion(t *testing.T) {
+	dir, err := ioutil.TempDir("", "cli")
+	expect(t, err, nil)
+	defer os.RemoveAll(dir)
+
+	_ = ioutil.WriteFile(dir+"/a.txt", []byte("@"+dir+"/b.txt"), 0644)
+	_ = ioutil.WriteFile(dir+"/b.txt", []byte("@"+dir+"/a.txt"), 0644)
+
 	app := newTestApp()
-	app.UseShortOptionHandling = true
-	command := &Command{
-		Name: "cmd",
-	}
-	subCommand := &Command{
-		Name: "sub",
-		Flags: []Flag{
-			&StringFlag{Name: "name", Aliases: []string{"n"}},
-		},
-	}
-	command.Subcommands = []*Command{subCommand}
-	app.Commands = []*Command{command}
+	app.ResponseFiles = true
+	app.Action = func(c *Context) error { return nil }
 
-	err := app.Run([]string{"", "cmd", "sub", "-n"})
-	expect(t, err, errors.New("flag needs an argument: -n"))
+	err = app.Run([]string{"", "@" + dir + "/a.txt"})
+	expect(t, err, fmt.Errorf("response file %q includes itself", dir+"/a.txt"))
 }
 
 func TestApp_Float64Flag(t *testing.T) {
@@ -902,7 +1956,7 @@ func TestApp_SetStdout(t *testing.T) {
 
 	err := app.Run([]string{"help"})
 
-	if err != nil {
+	if !errors.Is(err, ErrHelpShown) {
 		t.Fatalf("Run error: %s", err)
 	}
 
@@ -1216,7 +2270,7 @@ func TestRequiredFlagAppRunBehavior(t *testing.T) {
 			if _, ok := err.(requiredFlagsErr); test.expectedAnError && !ok {
 				t.Errorf("expected a requiredFlagsErr, but got: %s", err)
 			}
-			if !test.expectedAnError && err != nil {
+			if !test.expectedAnError && err != nil && !errors.Is(err, ErrHelpShown) {
 				t.Errorf("did not expected an error, but there was one: %s", err)
 			}
 		})
@@ -1462,7 +2516,7 @@ func TestApp_Run_CommandWithSubcommandHasHelpTopic(t *testing.T) {
 			app.Commands = []*Command{cmd}
 			err := app.Run(flagSet)
 
-			if err != nil {
+			if !errors.Is(err, ErrHelpShown) {
 				t.Error(err)
 			}
 
@@ -1502,7 +2556,7 @@ func TestApp_Run_SubcommandFullPath(t *testing.T) {
 	app.Commands = []*Command{cmd}
 
 	err := app.Run([]string{"command", "foo", "bar", "--help"})
-	if err != nil {
+	if !errors.Is(err, ErrHelpShown) {
 		t.Error(err)
 	}
 
@@ -1536,7 +2590,7 @@ func TestApp_Run_SubcommandHelpName(t *testing.T) {
 	app.Commands = []*Command{cmd}
 
 	err := app.Run([]string{"command", "foo", "bar", "--help"})
-	if err != nil {
+	if !errors.Is(err, ErrHelpShown) {
 		t.Error(err)
 	}
 
@@ -1571,7 +2625,7 @@ func TestApp_Run_CommandHelpName(t *testing.T) {
 	app.Commands = []*Command{cmd}
 
 	err := app.Run([]string{"command", "foo", "bar", "--help"})
-	if err != nil {
+	if !errors.Is(err, ErrHelpShown) {
 		t.Error(err)
 	}
 
@@ -1606,7 +2660,7 @@ func TestApp_Run_CommandSubcommandHelpName(t *testing.T) {
 	app.Commands = []*Command{cmd}
 
 	err := app.Run([]string{"command", "foo", "--help"})
-	if err != nil {
+	if !errors.Is(err, ErrHelpShown) {
 		t.Error(err)
 	}
 
@@ -1642,7 +2696,7 @@ func TestApp_Run_Help(t *testing.T) {
 			}
 
 			err := app.Run(args)
-			if err != nil {
+			if !errors.Is(err, ErrHelpShown) {
 				t.Error(err)
 			}
 
@@ -1675,7 +2729,7 @@ func TestApp_Run_Version(t *testing.T) {
 			}
 
 			err := app.Run(args)
-			if err != nil {
+			if !errors.Is(err, ErrHelpShown) {
 				t.Error(err)
 			}
 
@@ -2002,7 +3056,7 @@ func TestCustomFlagsUnused(t *testing.T) {
 	}
 
 	err := app.Run([]string{"foo"})
-	if err != nil {
+	if !errors.Is(err, ErrHelpShown) {
 		t.Errorf("Run returned unexpected error: %v", err)
 	}
 }
@@ -2014,7 +3068,7 @@ func TestCustomFlagsUsed(t *testing.T) {
 	}
 
 	err := app.Run([]string{"foo", "--custom=bar"})
-	if err != nil {
+	if !errors.Is(err, ErrHelpShown) {
 		t.Errorf("Run returned unexpected error: %v", err)
 	}
 }
@@ -2034,7 +3088,7 @@ func TestCustomHelpVersionFlags(t *testing.T) {
 	VersionFlag = &customBoolFlag{"version-custom"}
 
 	err := app.Run([]string{"foo", "--help-custom=bar"})
-	if err != nil {
+	if !errors.Is(err, ErrHelpShown) {
 		t.Errorf("Run returned unexpected error: %v", err)
 	}
 }
@@ -2203,3 +3257,232 @@ func TestDefaultApp(t *testing.T) {
 
 	t.Log(ip, p)
 }
+
+func TestApp_Parse(t *testing.T) {
+	os.Clearenv()
+	_ = os.Setenv("APP_NAME", "env")
+
+	app := newTestApp()
+	name := app.StringP("name", "n", "default", "a name", "APP_NAME")
+	count := app.IntP("count", "c", 1, "a count", "")
+
+	expect(t, app.Parsed(), false)
+	err := app.Parse([]string{"-c", "3", "a", "b"})
+	expect(t, err, nil)
+	expect(t, app.Parsed(), true)
+	expect(t, *name, "env")
+	expect(t, *count, 3)
+	expect(t, app.Args(), []string{"a", "b"})
+	expect(t, app.NArg(), 2)
+	expect(t, app.Arg(1), "b")
+	expect(t, app.Arg(2), "")
+	expect(t, app.NFlag(), 1)
+}
+
+func TestApp_ParseErrorHandling(t *testing.T) {
+	var buf bytes.Buffer
+	app := newTestApp()
+	app.ErrWriter = &buf
+	app.String("name", "default", "a name", "")
+
+	err := app.Parse([]string{"--help"})
+	expect(t, err, flag.ErrHelp)
+	if !strings.Contains(buf.String(), "--name string") {
+		t.Errorf("expected usage in the output, got %q", buf.String())
+	}
+
+	err = app.Parse([]string{"--xyzzy"})
+	expect(t, err, &UnknownFlagError{Name: "--xyzzy"})
+
+	lastExitCode = -1
+	app.ErrorHandling = ExitOnError
+	_ = app.Parse([]string{"--xyzzy"})
+	expect(t, lastExitCode, 2)
+
+	app.ErrorHandling = PanicOnError
+	defer func() {
+		expect(t, recover(), &UnknownFlagError{Name: "--xyzzy"})
+	}()
+	_ = app.Parse([]string{"--xyzzy"})
+	t.Error("expected Parse to panic")
+}
+
+func TestApp_Validate(t *testing.T) {
+	var shared string
+	app := &App{
+		Name:   "greet",
+		Writer: ioutil.Discard,
+		Flags: []Flag{
+			&StringFlag{Name: "config", Aliases: []string{"c"}, Persistent: true},
+			&StringFlag{Name: "name", Destination: &shared},
+			&IntFlag{Name: "count", Aliases: []string{"c"}},
+		},
+		Commands: []*Command{
+			{
+				Name:    "serve",
+				Aliases: []string{"s"},
+				Flags: []Flag{
+					&StringFlag{Name: "config"},
+					&StringFlag{Name: "host", Required: true, Value: "localhost"},
+					&StringFlag{Name: "user", Destination: &shared},
+					&StringFlag{},
+				},
+				Subcommands: []*Command{{}},
+			},
+			{Name: "status", Aliases: []string{"s"}},
+		},
+	}
+
+	err := app.Validate()
+	merr, ok := err.(MultiError)
+	if !ok {
+		t.Fatalf("expected a MultiError, got %v", err)
+	}
+	var msgs []string
+	for _, e := range merr.Errors() {
+		msgs = append(msgs, e.Error())
+	}
+	expect(t, msgs, []string{
+		`greet: flag name "c" is defined more than once`,
+		`greet serve: flag name "config" is already defined by the persistent flags of greet`,
+		`greet serve: required flag "host" has a default value`,
+		`greet serve: flag "user" shares its destination with the flag "name" of greet`,
+		`greet serve: flag #4 has no name`,
+		`greet serve: command #1 has no name`,
+		`greet: command name "s" of "status" is already used by "serve"`,
+	})
+
+	valid := &App{
+		Writer: ioutil.Discard,
+		Flags:  []Flag{&StringFlag{Name: "name", Required: true}},
+		Commands: []*Command{
+			{Name: "serve", Flags: []Flag{&StringFlag{Name: "name"}}},
+		},
+	}
+	expect(t, valid.Validate(), nil)
+}
+
+func TestApp_ValidateOnSetup(t *testing.T) {
+	app := &App{
+		Writer:          ioutil.Discard,
+		ValidateOnSetup: true,
+		Flags:           []Flag{&StringFlag{}},
+		Action: func(c *Context) error {
+			t.Error("expected the action not to run")
+			return nil
+		},
+	}
+
+	err := app.Run([]string{"greet"})
+	if err == nil || !strings.Contains(err.Error(), "flag #1 has no name") {
+		t.Errorf("expected the validation error, got %v", err)
+	}
+}
+
+func TestApp_Use(t *testing.T) {
+	var calls []string
+	trace := func(name string) Middleware {
+		return func(next ActionFunc) ActionFunc {
+			return func(c *Context) error {
+				calls = append(calls, name+" "+c.CommandPath())
+				return next(c)
+			}
+		}
+	}
+	action := func(c *Context) error {
+		calls = append(calls, "action")
+		return nil
+	}
+
+	set := &Command{Name: "set", Action: action}
+	set.Use(trace("set"))
+	config := &Command{Name: "config", Subcommands: []*Command{set}}
+	config.Use(trace("config"))
+	app := &App{
+		Name:   "greet",
+		Writer: ioutil.Discard,
+		Action: action,
+		Commands: []*Command{
+			config,
+			{Name: "status", Action: action},
+		},
+	}
+	app.Use(trace("outer"), trace("inner"))
+
+	tests := []struct {
+		args  []string
+		calls []string
+	}{
+		{
+			args:  []string{"greet"},
+			calls: []string{"outer greet", "inner greet", "action"},
+		},
+		{
+			args:  []string{"greet", "status"},
+			calls: []string{"outer greet status", "inner greet status", "action"},
+		},
+		{
+			args:  []string{"greet", "config", "set"},
+			calls: []string{"outer greet config set", "inner greet config set", "config greet config set", "set greet config set", "action"},
+		},
+	}
+	for _, test := range tests {
+		calls = nil
+		expect(t, app.Run(test.args), nil)
+		expect(t, calls, test.calls)
+	}
+
+	halt := errors.New("halt")
+	app.Use(func(next ActionFunc) ActionFunc {
+		return func(c *Context) error { return halt }
+	})
+	calls = nil
+	expect(t, app.Run([]string{"greet", "status"}), halt)
+	expect(t, calls, []string{"outer greet status", "inner greet status"})
+}
+
+func TestApp_RecoverPanics(t *testing.T) {
+	var buf bytes.Buffer
+	var handled *PanicError
+	app := &App{
+		Writer:        ioutil.Discard,
+		ErrWriter:     &buf,
+		RecoverPanics: true,
+		PanicExitCode: 3,
+		PanicHandler: func(c *Context, err *PanicError) {
+			handled = err
+		},
+		ExitErrHandler: func(c *Context, err error) {
+			lastExitCode = err.(ExitCoder).ExitCode()
+		},
+		Commands: []*Command{
+			{
+				Name:   "boom",
+				Action: func(c *Context) error { panic("boom") },
+			},
+			{
+				Name:   "before",
+				Before: func(c *Context) error { panic("before") },
+				Action: func(c *Context) error { return nil },
+			},
+		},
+	}
+
+	lastExitCode = 0
+	err := app.Run([]string{"app", "boom"})
+	perr, ok := err.(*PanicError)
+	if !ok {
+		t.Fatalf("expected a *PanicError, got %v", err)
+	}
+	expect(t, perr.Value, "boom")
+	expect(t, perr.Error(), "unexpected error: boom")
+	expect(t, handled, perr)
+	expect(t, lastExitCode, 3)
+	expect(t, buf.String(), "")
+
+	err = app.Run([]string{"app", "before", "--trace-panics"})
+	expect(t, err.(*PanicError).Value, "before")
+	if !strings.HasPrefix(buf.String(), "panic: before\n\ngoroutine ") {
+		t.Errorf("expected the stack trace, got %q", buf.String())
+	}
+}
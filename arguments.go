@@ -0,0 +1,256 @@
+// Copyright 2020 The vine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cli
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// ArgumentType is the type of the values of an Argument
+type ArgumentType int
+
+const (
+	// StringArg accepts any value
+	StringArg ArgumentType = iota
+	// IntArg accepts an integer
+	IntArg
+	// Float64Arg accepts a floating point number
+	Float64Arg
+	// PathArg accepts a path to a file or a directory
+	PathArg
+	// EnumArg accepts one of the Allowed values
+	EnumArg
+)
+
+// Argument describes a positional argument of an App or a Command. Optional
+// arguments must follow the required ones, and a variadic argument, taking
+// all the remaining values, must be the last one.
+type Argument struct {
+	Name    string
+	Usage   string
+	Type    ArgumentType
+	Allowed []string
+	// Optional arguments may be omitted, in which case Value is used
+	Optional bool
+	Value    string
+	// Variadic arguments take between Min and Max values, Max being
+	// unlimited when 0. Min defaults to 1 unless the argument is optional.
+	Variadic  bool
+	Min       int
+	Max       int
+	Validator ValidatorFunc
+}
+
+// Synopsis returns the argument as shown in the usage line, i.e. "<src>",
+// "[dst]" or "<files>..."
+func (a Argument) Synopsis() string {
+	s := a.Name
+	if a.Variadic {
+		s += "..."
+	}
+	if a.Optional {
+		return "[" + s + "]"
+	}
+	return "<" + s + ">"
+}
+
+// String returns a readable representation of the argument (for help output)
+func (a Argument) String() string {
+	return fmt.Sprintf("%s\t%s", a.Synopsis(), a.description())
+}
+
+func (a Argument) description() string {
+	description := a.Usage
+	if a.Type == EnumArg {
+		description += " (one of: " + strings.Join(a.Allowed, ", ") + ")"
+	}
+	if a.Value != "" {
+		description += " (default: " + a.Value + ")"
+	}
+	return strings.TrimSpace(description)
+}
+
+func (a Argument) counts() (min, max int) {
+	min = a.Min
+	if min == 0 && !a.Optional {
+		min = 1
+	}
+	return min, a.Max
+}
+
+// parse converts value to the type of the argument and runs its validator
+func (a Argument) parse(value string) (interface{}, error) {
+	var parsed interface{} = value
+	var err error
+	switch a.Type {
+	case IntArg:
+		var n int64
+		n, err = strconv.ParseInt(value, 0, 64)
+		parsed = int(n)
+	case Float64Arg:
+		parsed, err = strconv.ParseFloat(value, 64)
+	case EnumArg:
+		err = checkAllowed(a.Allowed, value)
+	}
+
+	if err == nil && a.Validator != nil {
+		err = a.Validator(parsed)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("invalid value %q for argument %s: %v", value, a.Name, err)
+	}
+	return parsed, nil
+}
+
+// parseArguments assigns the positional values to the arguments, checking
+// their number and their values
+func parseArguments(arguments []Argument, values []string) (map[string][]string, error) {
+	parsed := map[string][]string{}
+	i := 0
+	for _, a := range arguments {
+		if a.Variadic {
+			rest := values[i:]
+			min, max := a.counts()
+			if len(rest) < min {
+				if min == 1 {
+					return nil, fmt.Errorf("Required argument %q not set", a.Name)
+				}
+				return nil, fmt.Errorf("Argument %q requires at least %d values", a.Name, min)
+			}
+			if max > 0 && len(rest) > max {
+				return nil, fmt.Errorf("Argument %q accepts at most %d values", a.Name, max)
+			}
+			for _, value := range rest {
+				if _, err := a.parse(value); err != nil {
+					return nil, err
+				}
+			}
+			parsed[a.Name] = append([]string{}, rest...)
+			i = len(values)
+			continue
+		}
+
+		if i >= len(values) {
+			if !a.Optional {
+				return nil, fmt.Errorf("Required argument %q not set", a.Name)
+			}
+			if a.Value != "" {
+				parsed[a.Name] = []string{a.Value}
+			}
+			continue
+		}
+
+		if _, err := a.parse(values[i]); err != nil {
+			return nil, err
+		}
+		parsed[a.Name] = []string{values[i]}
+		i++
+	}
+
+	if i < len(values) {
+		return nil, fmt.Errorf("Unexpected argument %q", values[i])
+	}
+	return parsed, nil
+}
+
+// argumentsUsage returns the usage line of the arguments, i.e.
+// "<src> [dst]"
+func argumentsUsage(arguments []Argument) string {
+	var usage []string
+	for _, a := range arguments {
+		usage = append(usage, a.Synopsis())
+	}
+	return strings.Join(usage, " ")
+}
+
+// setArguments parses the positional arguments of the context
+func (c *Context) setArguments(arguments []Argument) error {
+	if len(arguments) == 0 {
+		return nil
+	}
+	values, err := parseArguments(arguments, c.Args().Slice())
+	if err != nil {
+		return err
+	}
+	c.arguments = values
+	return nil
+}
+
+// lookupArgument returns the values of the argument named name in the
+// context or in one of its parents
+func lookupArgument(name string, ctx *Context) []string {
+	for _, c := range ctx.Lineage() {
+		if values, ok := c.arguments[name]; ok {
+			return values
+		}
+	}
+	return nil
+}
+
+// Arg looks up the value of a positional Argument, returns "" if it is not
+// set. The first value of a variadic argument is returned.
+func (c *Context) Arg(name string) string {
+	if values := lookupArgument(name, c); len(values) > 0 {
+		return values[0]
+	}
+	return ""
+}
+
+// ArgInt looks up the value of a positional IntArg, returns 0 if it is not
+// set
+func (c *Context) ArgInt(name string) int {
+	n, err := strconv.ParseInt(c.Arg(name), 0, 64)
+	if err != nil {
+		return 0
+	}
+	return int(n)
+}
+
+// ArgFloat64 looks up the value of a positional Float64Arg, returns 0 if
+// it is not set
+func (c *Context) ArgFloat64(name string) float64 {
+	f, err := strconv.ParseFloat(c.Arg(name), 64)
+	if err != nil {
+		return 0
+	}
+	return f
+}
+
+// ArgSlice looks up the values of a variadic positional Argument, returns
+// nil if it is not set
+func (c *Context) ArgSlice(name string) []string {
+	if values := lookupArgument(name, c); values != nil {
+		return append([]string{}, values...)
+	}
+	return nil
+}
+
+// printArgumentSuggestions prints the allowed values of the EnumArg taking
+// the positional value at index position
+func printArgumentSuggestions(arguments []Argument, position int, writer io.Writer) {
+	for i, a := range arguments {
+		if i == position || (a.Variadic && i < position) {
+			if a.Type == EnumArg {
+				for _, value := range a.Allowed {
+					_, _ = fmt.Fprintln(writer, value)
+				}
+			}
+			return
+		}
+	}
+}
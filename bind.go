@@ -0,0 +1,250 @@
+// Copyright 2020 The vine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cli
+
+import (
+	"encoding"
+	"flag"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// FlagsFromStruct returns the flags described by the fields of the struct v
+// points to, with the fields as their destinations. The flags are described
+// by struct tags:
+//
+//	cli:"name,alias"    the flag name and aliases, "-" skips the field
+//	env:"X,Y"           environment variables
+//	usage:"..."         usage text
+//	default:"..."       default value, slices and maps separated by commas
+//	required:"true"     the flag is required
+//	hidden:"true"       the flag is hidden from help
+//
+// Without a name the field name is used, in lower kebab case. The fields of
+// a nested struct become flags prefixed with its name, i.e. the Host field of
+// a DB struct field becomes --db-host; the fields of an embedded struct are
+// not prefixed. Supported field types are string, bool, int, int64, uint,
+// uint64, float64, time.Duration, []string, []int, []int64, []float64 and
+// map[string]string. A struct field with a cli tag, or whose type implements
+// flag.Value or encoding.TextUnmarshaler such as time.Time, is not a nested
+// struct and is reported as unsupported.
+func FlagsFromStruct(v interface{}) ([]Flag, error) {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Ptr || rv.IsNil() || rv.Elem().Kind() != reflect.Struct {
+		return nil, fmt.Errorf("cli: FlagsFromStruct expects a pointer to a struct, got %T", v)
+	}
+	return structFlags(rv.Elem(), "")
+}
+
+// Bind appends the flags described by the fields of the struct v points to,
+// see FlagsFromStruct
+func (a *App) Bind(v interface{}) error {
+	flags, err := FlagsFromStruct(v)
+	if err != nil {
+		return err
+	}
+	a.Flags = append(a.Flags, flags...)
+	return nil
+}
+
+func structFlags(sv reflect.Value, prefix string) ([]Flag, error) {
+	var flags []Flag
+	st := sv.Type()
+	for i := 0; i < st.NumField(); i++ {
+		field := st.Field(i)
+		if field.PkgPath != "" {
+			continue
+		}
+
+		tag := field.Tag.Get("cli")
+		if tag == "-" {
+			continue
+		}
+
+		var names []string
+		if tag != "" {
+			names = strings.Split(tag, ",")
+		}
+		name := kebabCase(field.Name)
+		if len(names) > 0 && names[0] != "" {
+			name = names[0]
+		}
+
+		fv := sv.Field(i)
+		if tag == "" && isNestedStruct(field.Type) {
+			if fv.Kind() == reflect.Ptr {
+				if fv.IsNil() {
+					fv.Set(reflect.New(field.Type.Elem()))
+				}
+				fv = fv.Elem()
+			}
+			nestedPrefix := prefix + name + "-"
+			if field.Anonymous && tag == "" {
+				nestedPrefix = prefix
+			}
+			nested, err := structFlags(fv, nestedPrefix)
+			if err != nil {
+				return nil, err
+			}
+			flags = append(flags, nested...)
+			continue
+		}
+		if def, ok := field.Tag.Lookup("default"); ok {
+			if err := setFieldFromString(fv, def); err != nil {
+				return nil, fmt.Errorf("cli: invalid default %q for field %s: %s", def, field.Name, err)
+			}
+		}
+
+		f, err := fieldFlag(fv.Addr().Interface(), prefix+name)
+		if err != nil {
+			return nil, fmt.Errorf("cli: field %s: %s", field.Name, err)
+		}
+
+		var aliases []string
+		if len(names) > 1 {
+			aliases = names[1:]
+		}
+		var envVars []string
+		if env := field.Tag.Get("env"); env != "" {
+			envVars = strings.Split(env, ",")
+		}
+		required, _ := strconv.ParseBool(field.Tag.Get("required"))
+		hidden, _ := strconv.ParseBool(field.Tag.Get("hidden"))
+		describeFlag(f, aliases, field.Tag.Get("usage"), envVars, required, hidden)
+
+		flags = append(flags, f)
+	}
+	return flags, nil
+}
+
+var (
+	flagValueType       = reflect.TypeOf((*flag.Value)(nil)).Elem()
+	textUnmarshalerType = reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem()
+)
+
+// isNestedStruct returns true for the struct types, and pointers to them,
+// which are not values of their own
+func isNestedStruct(t reflect.Type) bool {
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t.Kind() != reflect.Struct {
+		return false
+	}
+	pt := reflect.PtrTo(t)
+	return !pt.Implements(flagValueType) && !pt.Implements(textUnmarshalerType)
+}
+
+// fieldFlag returns the flag with name storing its value in p
+func fieldFlag(p interface{}, name string) (Flag, error) {
+	switch p := p.(type) {
+	case *string:
+		return &StringFlag{Name: name, Value: *p, Destination: p}, nil
+	case *bool:
+		return &BoolFlag{Name: name, Value: *p, Destination: p}, nil
+	case *int:
+		return &IntFlag{Name: name, Value: *p, Destination: p}, nil
+	case *int64:
+		return &Int64Flag{Name: name, Value: *p, Destination: p}, nil
+	case *uint:
+		return &UintFlag{Name: name, Value: *p, Destination: p}, nil
+	case *uint64:
+		return &Uint64Flag{Name: name, Value: *p, Destination: p}, nil
+	case *float64:
+		return &Float64Flag{Name: name, Value: *p, Destination: p}, nil
+	case *time.Duration:
+		return &DurationFlag{Name: name, Value: *p, Destination: p}, nil
+	case *[]string:
+		return &StringSliceFlag{Name: name, Value: newStringSlice(*p, p)}, nil
+	case *[]int:
+		return &IntSliceFlag{Name: name, Value: newIntSlice(*p, p)}, nil
+	case *[]int64:
+		return &Int64SliceFlag{Name: name, Value: newInt64Slice(*p, p)}, nil
+	case *[]float64:
+		return &Float64SliceFlag{Name: name, Value: newFloat64Slice(*p, p)}, nil
+	case *map[string]string:
+		return &StringMapFlag{Name: name, Value: newStringMap(*p, p)}, nil
+	}
+	return nil, fmt.Errorf("unsupported field type %s", reflect.TypeOf(p).Elem())
+}
+
+// describeFlag fills in the descriptive fields shared by the flags returned
+// by fieldFlag
+func describeFlag(f Flag, aliases []string, usage string, envVars []string, required, hidden bool) {
+	fv := reflect.ValueOf(f).Elem()
+	fv.FieldByName("Aliases").Set(reflect.ValueOf(aliases))
+	fv.FieldByName("Usage").SetString(usage)
+	fv.FieldByName("EnvVars").Set(reflect.ValueOf(envVars))
+	fv.FieldByName("Required").SetBool(required)
+	fv.FieldByName("Hidden").SetBool(hidden)
+}
+
+// setFieldFromString parses s into the field, as a flag of its type would
+func setFieldFromString(fv reflect.Value, s string) error {
+	f, err := fieldFlag(fv.Addr().Interface(), "default")
+	if err != nil {
+		return err
+	}
+
+	switch f := f.(type) {
+	case *StringSliceFlag:
+		return setSliceDefault(f.Value, s)
+	case *IntSliceFlag:
+		return setSliceDefault(f.Value, s)
+	case *Int64SliceFlag:
+		return setSliceDefault(f.Value, s)
+	case *Float64SliceFlag:
+		return setSliceDefault(f.Value, s)
+	case *StringMapFlag:
+		return f.Value.Set(s)
+	}
+
+	set := flag.NewFlagSet("default", flag.ContinueOnError)
+	if err := f.Apply(set); err != nil {
+		return err
+	}
+	return set.Set("default", s)
+}
+
+func setSliceDefault(value interface{ Set(string) error }, s string) error {
+	for _, item := range strings.Split(s, ",") {
+		if err := value.Set(strings.TrimSpace(item)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// kebabCase converts a Go identifier to lower kebab case, i.e. MaxConns
+// becomes max-conns and HTTPAddr http-addr
+func kebabCase(s string) string {
+	runes := []rune(s)
+	var b strings.Builder
+	for i, r := range runes {
+		if unicode.IsUpper(r) {
+			if i > 0 && (unicode.IsLower(runes[i-1]) ||
+				(i+1 < len(runes) && unicode.IsLower(runes[i+1]) && unicode.IsUpper(runes[i-1]))) {
+				b.WriteByte('-')
+			}
+			r = unicode.ToLower(r)
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
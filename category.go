@@ -84,7 +84,7 @@ func (c *commandCategory) VisibleCommands() []*Command {
 
 	var ret []*Command
 	for _, command := range c.commands {
-		if !command.Hidden {
+		if !command.Hidden && !command.isDeprecated() {
 			ret = append(ret, command)
 		}
 	}
@@ -35,6 +35,8 @@ type Command struct {
 	Description string
 	// A short description of the arguments of this command
 	ArgsUsage string
+	// List of positional arguments, generating ArgsUsage when it is empty
+	Arguments []Argument
 	// The category the command is part of
 	Category string
 	// The function to call when checking for bash command completions
@@ -53,12 +55,23 @@ type Command struct {
 	Subcommands []*Command
 	// List of flags to parse
 	Flags []Flag
+	// Constraints between the flags, checked with the required flags
+	FlagGroups []FlagGroup
+	// EnvPrefix replaces the prefix of the environment variables bound to
+	// the flags of the command, defaulting to the one of its parent followed
+	// by the name of the command
+	EnvPrefix string
 	// Treat all flags as normal arguments if true
 	SkipFlagParsing bool
 	// Boolean to hide built-in help command
 	HideHelp bool
 	// Boolean to hide this command from help or completion
 	Hidden bool
+	// Deprecated marks the command as deprecated, hiding it from help and
+	// completion, with the message shown when it is used
+	Deprecated string
+	// Name of the command to use instead of this deprecated one
+	ReplacedBy string
 	// Boolean to enable short-option handling so user can combine several
 	// single-character bool arguments into one
 	// i.e. foobar -o -v -> foobar -ov
@@ -67,6 +80,18 @@ type Command struct {
 	// Full name of command for help, defaults to full command name, including parent commands.
 	HelpName        string
 	commandNamePath []string
+	// persistent maps the persistent flags of the ancestors to their flag
+	// sets while running
+	persistent map[string]*persistentSet
+	// inherited are the persistent flags of the parent commands, as shown
+	// in help
+	inherited []Flag
+	// allowAbbreviations is inherited from the App while running
+	allowAbbreviations bool
+	// suggestionsDistance is inherited from the App while running
+	suggestionsDistance int
+	// middlewares wrap the action of the command and of its subcommands
+	middlewares []Middleware
 
 	// CustomHelpTemplate the text template for the command help topic.
 	// cli.go uses text/template to render templates. You can
@@ -101,6 +126,10 @@ func (c *Command) FullName() string {
 
 // Run invokes the command given the context, parses ctx.Args() to generate command-specific flags
 func (c *Command) Run(ctx *Context) (err error) {
+	if err := c.checkDeprecated(ctx); err != nil {
+		return err
+	}
+
 	if len(c.Subcommands) > 0 {
 		return c.startApp(ctx)
 	}
@@ -110,10 +139,17 @@ func (c *Command) Run(ctx *Context) (err error) {
 		c.appendFlag(HelpFlag)
 	}
 
+	if !ctx.App.HideDebugFlags && DebugFlagsFlag != nil {
+		c.appendFlag(DebugFlagsFlag)
+	}
+
 	if ctx.App.UseShortOptionHandling {
 		c.UseShortOptionHandling = true
 	}
+	c.allowAbbreviations = ctx.App.AllowAbbreviations
+	c.suggestionsDistance = ctx.App.suggestionsDistance()
 
+	c.persistent = persistentFlagSets(ctx)
 	set, err := c.parseFlags(ctx.Args(), ctx.shellComplete)
 
 	context := NewContext(ctx.App, set, ctx)
@@ -135,15 +171,46 @@ func (c *Command) Run(ctx *Context) (err error) {
 	}
 
 	if checkCommandHelp(context, c.Name) {
-		return nil
+		return helpShown(context)
 	}
 
-	cerr := checkRequiredFlags(c.Flags, context)
+	flags := checkedFlags(c.Flags, context, false)
+	if derr := checkDeprecatedFlags(flags, context); derr != nil {
+		return derr
+	}
+
+	cerr := checkRequiredFlags(flags, context)
 	if cerr != nil {
 		_ = ShowCommandHelp(context, c.Name)
 		return cerr
 	}
 
+	if gerr := checkFlagGroups(c.FlagGroups, c.Flags, context); gerr != nil {
+		_ = ShowCommandHelp(context, c.Name)
+		return gerr
+	}
+
+	verr := checkFlagValidators(flags, context)
+	if verr == nil {
+		verr = context.setArguments(c.Arguments)
+	}
+	if verr != nil {
+		if c.OnUsageError != nil {
+			err = c.OnUsageError(context, verr, false)
+			context.App.handleExitCoder(context, err)
+			return err
+		}
+		_, _ = fmt.Fprintln(context.App.Writer, "Incorrect Usage:", verr.Error())
+		_, _ = fmt.Fprintln(context.App.Writer)
+		_ = ShowCommandHelp(context, c.Name)
+		return verr
+	}
+
+	if ferr := runFlagActions(flags, context); ferr != nil {
+		context.App.handleExitCoder(context, ferr)
+		return ferr
+	}
+
 	if c.After != nil {
 		defer func() {
 			afterErr := c.After(context)
@@ -172,7 +239,11 @@ func (c *Command) Run(ctx *Context) (err error) {
 	}
 
 	context.Command = c
-	err = c.Action(context)
+	if checkDebugFlags(context) {
+		return nil
+	}
+
+	err = wrapAction(c.Action, chainMiddlewares(context.App.middlewares, c.middlewares))(context)
 
 	if err != nil {
 		context.App.handleExitCoder(context, err)
@@ -188,6 +259,34 @@ func (c *Command) useShortOptionHandling() bool {
 	return c.UseShortOptionHandling
 }
 
+func (c *Command) isSubcommand(name string) bool {
+	for _, sc := range c.Subcommands {
+		if sc.HasName(name) {
+			return true
+		}
+	}
+	return false
+}
+
+func (c *Command) readsValueFromFile(name string) bool {
+	return readsValueFromFile(c.Flags, c.persistent, name)
+}
+
+func (c *Command) persistentFlagSet(name string) *persistentSet {
+	return c.persistent[name]
+}
+
+func (c *Command) suggestFlags(name string) []string {
+	return suggestFlags(c.Flags, c.persistent, name, c.suggestionsDistance)
+}
+
+func (c *Command) expandFlagName(name string) (string, error) {
+	if !c.allowAbbreviations {
+		return name, nil
+	}
+	return expandFlagName(c.Flags, c.persistent, name)
+}
+
 func (c *Command) parseFlags(args Args, shellComplete bool) (*flag.FlagSet, error) {
 	set, err := c.newFlagSet()
 	if err != nil {
@@ -241,6 +340,7 @@ func (c *Command) startApp(ctx *Context) error {
 	app.Usage = c.Usage
 	app.Description = c.Description
 	app.ArgsUsage = c.ArgsUsage
+	app.Arguments = c.Arguments
 
 	// set CommandNotFound
 	app.CommandNotFound = ctx.App.CommandNotFound
@@ -249,15 +349,23 @@ func (c *Command) startApp(ctx *Context) error {
 	// set the flags and commands
 	app.Commands = c.Subcommands
 	app.Flags = c.Flags
+	app.FlagGroups = c.FlagGroups
 	app.HideHelp = c.HideHelp
 
 	app.Version = ctx.App.Version
 	app.HideVersion = ctx.App.HideVersion
+	app.HideDebugFlags = ctx.App.HideDebugFlags
 	app.Compiled = ctx.App.Compiled
 	app.Writer = ctx.App.Writer
 	app.ErrWriter = ctx.App.ErrWriter
 	app.ExitErrHandler = ctx.App.ExitErrHandler
 	app.UseShortOptionHandling = ctx.App.UseShortOptionHandling
+	app.StrictDeprecations = ctx.App.StrictDeprecations
+	app.ExitOnHelp = ctx.App.ExitOnHelp
+	app.AllowAbbreviations = ctx.App.AllowAbbreviations
+	app.DisableSuggestions = ctx.App.DisableSuggestions
+	app.SuggestionsMinDistance = ctx.App.SuggestionsMinDistance
+	app.StrictLookup = ctx.App.StrictLookup
 
 	app.categories = newCommandCategories()
 	for _, command := range c.Subcommands {
@@ -281,6 +389,7 @@ func (c *Command) startApp(ctx *Context) error {
 		app.Action = helpSubcommand.Action
 	}
 	app.OnUsageError = c.OnUsageError
+	app.middlewares = chainMiddlewares(ctx.App.middlewares, c.middlewares)
 
 	for index, cc := range app.Commands {
 		app.Commands[index].commandNamePath = []string{c.Name, cc.Name}
@@ -289,11 +398,26 @@ func (c *Command) startApp(ctx *Context) error {
 	return app.RunAsSubcommand(ctx)
 }
 
+// ArgumentsUsage returns ArgsUsage, or the usage generated from the
+// Arguments when it is empty
+func (c *Command) ArgumentsUsage() string {
+	if c.ArgsUsage == "" {
+		return argumentsUsage(c.Arguments)
+	}
+	return c.ArgsUsage
+}
+
 // VisibleFlags returns a slice of the Flags with Hidden=false
 func (c *Command) VisibleFlags() []Flag {
 	return visibleFlags(c.Flags)
 }
 
+// VisibleInheritedFlags returns a slice of the persistent flags of the
+// parent commands with Hidden=false
+func (c *Command) VisibleInheritedFlags() []Flag {
+	return visibleFlags(c.inherited)
+}
+
 func (c *Command) appendFlag(fl Flag) {
 	if !hasFlag(c.Flags, fl) {
 		c.Flags = append(c.Flags, fl)
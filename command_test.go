@@ -18,11 +18,11 @@ func TestCommandFlagParsing(t *testing.T) {
 		expectedErr            error
 	}{
 		// Test normal "not ignoring flags" flow
-		{testArgs: []string{"test-cmd", "-break", "blah", "blah"}, skipFlagParsing: false, useShortOptionHandling: false, expectedErr: errors.New("flag provided but not defined: -break")},
-		{testArgs: []string{"test-cmd", "blah", "blah"}, skipFlagParsing: true, useShortOptionHandling: false, expectedErr: nil},   // Test SkipFlagParsing without any args that look like flags
-		{testArgs: []string{"test-cmd", "blah", "-break"}, skipFlagParsing: true, useShortOptionHandling: false, expectedErr: nil}, // Test SkipFlagParsing with random flag arg
-		{testArgs: []string{"test-cmd", "blah", "-help"}, skipFlagParsing: true, useShortOptionHandling: false, expectedErr: nil},  // Test SkipFlagParsing with "special" help flag arg
-		{testArgs: []string{"test-cmd", "blah", "-h"}, skipFlagParsing: false, useShortOptionHandling: true, expectedErr: nil},     // Test UseShortOptionHandling
+		{testArgs: []string{"test-cmd", "-break", "blah", "blah"}, skipFlagParsing: false, useShortOptionHandling: false, expectedErr: &UnknownFlagError{Name: "-break"}},
+		{testArgs: []string{"test-cmd", "blah", "blah"}, skipFlagParsing: true, useShortOptionHandling: false, expectedErr: nil},        // Test SkipFlagParsing without any args that look like flags
+		{testArgs: []string{"test-cmd", "blah", "-break"}, skipFlagParsing: true, useShortOptionHandling: false, expectedErr: nil},      // Test SkipFlagParsing with random flag arg
+		{testArgs: []string{"test-cmd", "blah", "-help"}, skipFlagParsing: true, useShortOptionHandling: false, expectedErr: nil},       // Test SkipFlagParsing with "special" help flag arg
+		{testArgs: []string{"test-cmd", "blah", "-h"}, skipFlagParsing: false, useShortOptionHandling: true, expectedErr: ErrHelpShown}, // Test UseShortOptionHandling
 	}
 
 	for _, c := range cases {
@@ -61,17 +61,22 @@ func TestParseAndRunShortOpts(t *testing.T) {
 		{testArgs: args{"foo", "test", "-af"}, expectedErr: nil, expectedArgs: &args{}},
 		{testArgs: args{"foo", "test", "-cf"}, expectedErr: nil, expectedArgs: &args{}},
 		{testArgs: args{"foo", "test", "-acf"}, expectedErr: nil, expectedArgs: &args{}},
-		{testArgs: args{"foo", "test", "--acf"}, expectedErr: errors.New("flag provided but not defined: -acf"), expectedArgs: nil},
-		{testArgs: args{"foo", "test", "-invalid"}, expectedErr: errors.New("flag provided but not defined: -invalid"), expectedArgs: nil},
-		{testArgs: args{"foo", "test", "-acf", "-invalid"}, expectedErr: errors.New("flag provided but not defined: -invalid"), expectedArgs: nil},
-		{testArgs: args{"foo", "test", "--invalid"}, expectedErr: errors.New("flag provided but not defined: -invalid"), expectedArgs: nil},
-		{testArgs: args{"foo", "test", "-acf", "--invalid"}, expectedErr: errors.New("flag provided but not defined: -invalid"), expectedArgs: nil},
-		{testArgs: args{"foo", "test", "-acf", "arg1", "-invalid"}, expectedErr: nil, expectedArgs: &args{"arg1", "-invalid"}},
-		{testArgs: args{"foo", "test", "-acf", "arg1", "--invalid"}, expectedErr: nil, expectedArgs: &args{"arg1", "--invalid"}},
-		{testArgs: args{"foo", "test", "-acfi", "not-arg", "arg1", "-invalid"}, expectedErr: nil, expectedArgs: &args{"arg1", "-invalid"}},
+		{testArgs: args{"foo", "test", "--acf"}, expectedErr: &UnknownFlagError{Name: "--acf", Suggestions: []string{"--abc"}}, expectedArgs: nil},
+		{testArgs: args{"foo", "test", "-xyz"}, expectedErr: &UnknownFlagError{Name: "-x"}, expectedArgs: nil},
+		{testArgs: args{"foo", "test", "-acf", "-xyz"}, expectedErr: &UnknownFlagError{Name: "-x"}, expectedArgs: nil},
+		{testArgs: args{"foo", "test", "--invalid"}, expectedErr: &UnknownFlagError{Name: "--invalid"}, expectedArgs: nil},
+		{testArgs: args{"foo", "test", "-acf", "--invalid"}, expectedErr: &UnknownFlagError{Name: "--invalid"}, expectedArgs: nil},
+		{testArgs: args{"foo", "test", "-acf", "arg1", "--invalid"}, expectedErr: &UnknownFlagError{Name: "--invalid"}, expectedArgs: nil},
+		{testArgs: args{"foo", "test", "-acf", "arg1", "--", "--invalid"}, expectedErr: nil, expectedArgs: &args{"arg1", "--invalid"}},
+		{testArgs: args{"foo", "test", "-acfi", "not-arg", "arg1", "-a"}, expectedErr: nil, expectedArgs: &args{"arg1"}},
+		{testArgs: args{"foo", "test", "-invalid"}, expectedErr: nil, expectedArgs: &args{}},
 		{testArgs: args{"foo", "test", "-i", "ivalue"}, expectedErr: nil, expectedArgs: &args{}},
+		{testArgs: args{"foo", "test", "-i=ivalue"}, expectedErr: nil, expectedArgs: &args{}},
 		{testArgs: args{"foo", "test", "-i", "ivalue", "arg1"}, expectedErr: nil, expectedArgs: &args{"arg1"}},
+		{testArgs: args{"foo", "test", "arg1", "-i", "ivalue", "arg2"}, expectedErr: nil, expectedArgs: &args{"arg1", "arg2"}},
+		{testArgs: args{"foo", "test", "--ijk=ivalue", "arg1"}, expectedErr: nil, expectedArgs: &args{"arg1"}},
 		{testArgs: args{"foo", "test", "-i"}, expectedErr: errors.New("flag needs an argument: -i"), expectedArgs: nil},
+		{testArgs: args{"foo", "test", "--ijk"}, expectedErr: errors.New("flag needs an argument: --ijk"), expectedArgs: nil},
 	}
 
 	for _, c := range cases {
@@ -0,0 +1,213 @@
+// Copyright 2020 The vine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cli
+
+import (
+	"flag"
+	"fmt"
+	"os"
+)
+
+// ErrorHandling defines how App.Parse behaves if the parse fails, mirroring
+// flag.ErrorHandling
+type ErrorHandling int
+
+const (
+	// ContinueOnError returns the error
+	ContinueOnError ErrorHandling = iota
+	// ExitOnError exits through OsExiter with status 2, or 0 for -h/--help
+	ExitOnError
+	// PanicOnError panics with the error
+	PanicOnError
+)
+
+// Usage prints a usage message documenting all defined command-line flags
+// of CommandLine to its ErrWriter. It is called when Parse fails, and may be
+// changed to point to a custom function.
+var Usage = func() {
+	defaultUsage(CommandLine)
+}
+
+func defaultUsage(a *App) {
+	_, _ = fmt.Fprintf(a.errWriter(), "Usage of %s:\n", a.Name)
+	a.PrintDefaults()
+}
+
+// Parse parses the flags of the app from arguments, which should not include
+// the program name, without running any action or command. The values are
+// resolved from the command line, the environment and the files the same
+// way Run does. Errors are handled according to ErrorHandling, a request for
+// help is reported as flag.ErrHelp.
+func (a *App) Parse(arguments []string) error {
+	a.Setup()
+	a.parsed = true
+
+	err := a.setupErr
+	if err == nil && a.ResponseFiles {
+		arguments, err = a.expandResponseFiles(arguments)
+	}
+
+	var set *flag.FlagSet
+	if err == nil {
+		set, err = a.newFlagSet()
+	}
+	if err == nil {
+		err = parseIter(set, a, arguments, false)
+	}
+	if set == nil {
+		set = flag.NewFlagSet(a.Name, flag.ContinueOnError)
+	}
+	context := NewContext(a, set, nil)
+	a.parseContext = context
+
+	if err == nil {
+		err = normalizeFlags(a.Flags, set)
+	}
+	if err == nil && !a.HideHelp && checkHelp(context) {
+		err = flag.ErrHelp
+	}
+	if err == nil {
+		err = checkParsedFlags(a, context)
+	}
+	if err == nil {
+		return nil
+	}
+
+	if err != flag.ErrHelp {
+		_, _ = fmt.Fprintln(a.errWriter(), err)
+	}
+	if a == CommandLine {
+		Usage()
+	} else {
+		defaultUsage(a)
+	}
+
+	switch a.ErrorHandling {
+	case ExitOnError:
+		if err == flag.ErrHelp {
+			OsExiter(0)
+		} else {
+			OsExiter(2)
+		}
+	case PanicOnError:
+		panic(err)
+	}
+	return err
+}
+
+func checkParsedFlags(a *App, context *Context) error {
+	if err := checkDeprecatedFlags(a.Flags, context); err != nil {
+		return err
+	}
+	if err := checkRequiredFlags(a.Flags, context); err != nil {
+		return err
+	}
+	if err := checkFlagGroups(a.FlagGroups, a.Flags, context); err != nil {
+		return err
+	}
+	if err := checkFlagValidators(a.Flags, context); err != nil {
+		return err
+	}
+	return runFlagActions(a.Flags, context)
+}
+
+// Parsed reports whether Parse has been called
+func (a *App) Parsed() bool {
+	return a.parsed
+}
+
+// Args returns the non-flag arguments left by Parse
+func (a *App) Args() []string {
+	if a.parseContext == nil {
+		return []string{}
+	}
+	return a.parseContext.Args().Slice()
+}
+
+// NArg returns the number of the non-flag arguments left by Parse
+func (a *App) NArg() int {
+	return len(a.Args())
+}
+
+// Arg returns the i'th non-flag argument left by Parse, or an empty string
+// if it does not exist
+func (a *App) Arg(i int) string {
+	args := a.Args()
+	if i < 0 || i >= len(args) {
+		return ""
+	}
+	return args[i]
+}
+
+// NFlag returns the number of flags set on the command line by Parse
+func (a *App) NFlag() int {
+	if a.parseContext == nil {
+		return 0
+	}
+	n := 0
+	for _, f := range a.Flags {
+		if flagSource(f, a.parseContext).Kind == CommandLineSource {
+			n++
+		}
+	}
+	return n
+}
+
+// PrintDefaults prints the visible flags of the app to its ErrWriter, with
+// the CommandLineHelpTemplate
+func (a *App) PrintDefaults() {
+	HelpPrinter(a.errWriter(), CommandLineHelpTemplate, a)
+}
+
+// Parse parses the command-line flags from os.Args[1:] into CommandLine.
+// Must be called after all flags are defined and before flags are accessed
+// by the program.
+func Parse() {
+	// errors are handled according to CommandLine.ErrorHandling
+	_ = CommandLine.Parse(os.Args[1:])
+}
+
+// Parsed reports whether the command-line flags have been parsed
+func Parsed() bool {
+	return CommandLine.Parsed()
+}
+
+// ParsedArgs returns the non-flag command-line arguments, as flag.Args does.
+// Args already names the type of the arguments of a Context.
+func ParsedArgs() []string {
+	return CommandLine.Args()
+}
+
+// NArg returns the number of non-flag command-line arguments
+func NArg() int {
+	return CommandLine.NArg()
+}
+
+// Arg returns the i'th non-flag command-line argument, or an empty string if
+// it does not exist
+func Arg(i int) string {
+	return CommandLine.Arg(i)
+}
+
+// NFlag returns the number of command-line flags that have been set
+func NFlag() int {
+	return CommandLine.NFlag()
+}
+
+// PrintDefaults prints the visible command-line flags to the ErrWriter of
+// CommandLine
+func PrintDefaults() {
+	CommandLine.PrintDefaults()
+}
@@ -33,6 +33,8 @@ type Context struct {
 	shellComplete bool
 	flagSet       *flag.FlagSet
 	parentContext *Context
+	arguments     map[string][]string
+	sources       map[string]ValueSource
 }
 
 // NewContext creates a new context. For use in when invoking an App or Command action.
@@ -120,9 +122,41 @@ func (c *Context) Lineage() []*Context {
 	return lineage
 }
 
-// Value returns the value of the flag corresponding to `name`
+// Value returns the value of the flag corresponding to `name`, or nil if
+// there is none
 func (c *Context) Value(name string) interface{} {
-	return c.flagSet.Lookup(name).Value.(flag.Getter).Get()
+	if fs := c.strictFlagSet(name); fs != nil {
+		return lookupValue(name, fs)
+	}
+	return nil
+}
+
+// GetValue returns the value of the flag corresponding to `name`, returns an
+// error if the flag is not defined
+func (c *Context) GetValue(name string) (interface{}, error) {
+	fs, err := c.definedFlagSet(name)
+	if err != nil {
+		return nil, err
+	}
+	return lookupValue(name, fs), nil
+}
+
+func lookupValue(name string, set *flag.FlagSet) interface{} {
+	if getter, ok := set.Lookup(name).Value.(flag.Getter); ok {
+		return getter.Get()
+	}
+	return nil
+}
+
+// Lookup returns the flag defined with the name, or one of its aliases, by
+// the command of the context or by its ancestors
+func (c *Context) Lookup(name string) (Flag, bool) {
+	for _, ctx := range c.Lineage() {
+		if f := lookupFlag(name, ctx); f != nil {
+			return f, true
+		}
+	}
+	return nil, false
 }
 
 // Args returns the command line arguments associated with the context.
@@ -174,6 +208,35 @@ func lookupFlagSet(name string, ctx *Context) *flag.FlagSet {
 	return nil
 }
 
+// FlagNotDefinedError is returned by the Get accessors of a Context for a
+// name that no flag is defined with
+type FlagNotDefinedError struct {
+	Name string
+}
+
+func (e *FlagNotDefinedError) Error() string {
+	return fmt.Sprintf("flag %q not defined in this command or its ancestors", e.Name)
+}
+
+// definedFlagSet is like lookupFlagSet, but returns a *FlagNotDefinedError
+// if the flag is not found
+func (c *Context) definedFlagSet(name string) (*flag.FlagSet, error) {
+	if fs := lookupFlagSet(name, c); fs != nil {
+		return fs, nil
+	}
+	return nil, &FlagNotDefinedError{Name: name}
+}
+
+// strictFlagSet is like lookupFlagSet, but panics if the flag is not found
+// and the app has StrictLookup set
+func (c *Context) strictFlagSet(name string) *flag.FlagSet {
+	fs, err := c.definedFlagSet(name)
+	if err != nil && c.App != nil && c.App.StrictLookup {
+		panic(err)
+	}
+	return fs
+}
+
 func copyFlag(name string, ff *flag.Flag, set *flag.FlagSet) {
 	switch ff.Value.(type) {
 	case Serializer:
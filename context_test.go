@@ -3,6 +3,7 @@ package cli
 import (
 	"context"
 	"flag"
+	"io/ioutil"
 	"os"
 	"sort"
 	"strings"
@@ -314,6 +315,83 @@ func TestContext_lookupFlagSet(t *testing.T) {
 	}
 }
 
+func TestContext_GetInt(t *testing.T) {
+	set := flag.NewFlagSet("test", 0)
+	set.Int("myflag", 12, "doc")
+	parentSet := flag.NewFlagSet("test", 0)
+	parentSet.Int("top-flag", 13, "doc")
+	parentCtx := NewContext(nil, parentSet, nil)
+	c := NewContext(nil, set, parentCtx)
+
+	i, err := c.GetInt("top-flag")
+	expect(t, err, nil)
+	expect(t, i, 13)
+
+	i, err = c.GetInt("frob")
+	expect(t, i, 0)
+	expect(t, err, &FlagNotDefinedError{Name: "frob"})
+	expect(t, err.Error(), `flag "frob" not defined in this command or its ancestors`)
+
+	v, err := c.GetValue("myflag")
+	expect(t, err, nil)
+	expect(t, v, 12)
+	expect(t, c.Value("frob"), nil)
+}
+
+func TestContext_Lookup(t *testing.T) {
+	app := &App{Flags: []Flag{&StringFlag{Name: "top-flag", Aliases: []string{"t"}}}}
+	set := flag.NewFlagSet("test", 0)
+	set.Bool("local-flag", false, "doc")
+	parentCtx := NewContext(app, flag.NewFlagSet("test", 0), nil)
+	ctx := NewContext(app, set, parentCtx)
+	ctx.Command = &Command{Flags: []Flag{&BoolFlag{Name: "local-flag"}}}
+
+	f, ok := ctx.Lookup("t")
+	expect(t, ok, true)
+	expect(t, f, app.Flags[0])
+
+	f, ok = ctx.Lookup("local-flag")
+	expect(t, ok, true)
+	expect(t, f, ctx.Command.Flags[0])
+
+	_, ok = ctx.Lookup("frob")
+	expect(t, ok, false)
+}
+
+func TestContext_StrictLookup(t *testing.T) {
+	set := flag.NewFlagSet("test", 0)
+	set.String("myflag", "value", "doc")
+	c := NewContext(&App{StrictLookup: true}, set, nil)
+	expect(t, c.String("myflag"), "value")
+
+	defer func() {
+		expect(t, recover(), &FlagNotDefinedError{Name: "myflga"})
+	}()
+	c.String("myflga")
+	t.Error("expected String to panic")
+}
+
+func TestContext_GetInt64Slice(t *testing.T) {
+	parentSet := flag.NewFlagSet("test", 0)
+	parentSet.Var(NewInt64Slice(1, 2), "ids", "doc")
+	parentCtx := NewContext(nil, parentSet, nil)
+	c := NewContext(&App{StrictLookup: true}, flag.NewFlagSet("test", 0), parentCtx)
+
+	expect(t, c.Int64Slice("ids"), []int64{1, 2})
+	ids, err := c.GetInt64Slice("ids")
+	expect(t, err, nil)
+	expect(t, ids, []int64{1, 2})
+
+	_, err = c.GetInt64Slice("idz")
+	expect(t, err, &FlagNotDefinedError{Name: "idz"})
+
+	defer func() {
+		expect(t, recover(), &FlagNotDefinedError{Name: "idz"})
+	}()
+	c.Int64Slice("idz")
+	t.Error("expected Int64Slice to panic")
+}
+
 func TestNonNilContext(t *testing.T) {
 	ctx := NewContext(nil, nil, nil)
 	if ctx.Context == nil {
@@ -572,4 +650,256 @@ func TestCheckRequiredFlags(t *testing.T) {
 			}
 		})
 	}
-}
\ No newline at end of file
+}
+func TestCheckFlagGroups(t *testing.T) {
+	tdata := []struct {
+		testCase    string
+		groups      []FlagGroup
+		parseInput  []string
+		expectedErr string
+	}{
+		{
+			testCase: "mutually_exclusive_none_set",
+			groups:   []FlagGroup{MutuallyExclusive("token", "token-file")},
+		},
+		{
+			testCase:   "mutually_exclusive_one_set",
+			groups:     []FlagGroup{MutuallyExclusive("token", "token-file")},
+			parseInput: []string{"--token", "abc"},
+		},
+		{
+			testCase:    "mutually_exclusive_both_set",
+			groups:      []FlagGroup{MutuallyExclusive("token", "token-file")},
+			parseInput:  []string{"--token", "abc", "--token-file", "f"},
+			expectedErr: `Flags "token, token-file" are mutually exclusive`,
+		},
+		{
+			testCase:    "mutually_exclusive_set_by_alias",
+			groups:      []FlagGroup{MutuallyExclusive("token", "token-file")},
+			parseInput:  []string{"-t", "abc", "--token-file", "f"},
+			expectedErr: `Flags "token, token-file" are mutually exclusive`,
+		},
+		{
+			testCase: "required_together_none_set",
+			groups:   []FlagGroup{RequiredTogether("cert", "key")},
+		},
+		{
+			testCase:   "required_together_all_set",
+			groups:     []FlagGroup{RequiredTogether("cert", "key")},
+			parseInput: []string{"--cert", "c", "--key", "k"},
+		},
+		{
+			testCase:    "required_together_one_missing",
+			groups:      []FlagGroup{RequiredTogether("cert", "key")},
+			parseInput:  []string{"--cert", "c"},
+			expectedErr: `Flags "cert, key" must be set together, "key" not set`,
+		},
+		{
+			testCase:    "exactly_one_none_set",
+			groups:      []FlagGroup{ExactlyOne("all", "name")},
+			expectedErr: `One of flags "all, name" must be set`,
+		},
+		{
+			testCase:   "exactly_one_set",
+			groups:     []FlagGroup{ExactlyOne("all", "name")},
+			parseInput: []string{"--all"},
+		},
+		{
+			testCase:    "exactly_one_both_set",
+			groups:      []FlagGroup{ExactlyOne("all", "name")},
+			parseInput:  []string{"--all", "--name", "n"},
+			expectedErr: `Only one of flags "all, name" can be set`,
+		},
+		{
+			testCase:    "at_least_one_none_set",
+			groups:      []FlagGroup{AtLeastOne("cert", "key")},
+			expectedErr: `At least one of flags "cert, key" must be set`,
+		},
+		{
+			testCase:   "at_least_one_both_set",
+			groups:     []FlagGroup{AtLeastOne("cert", "key")},
+			parseInput: []string{"--cert", "c", "--key", "k"},
+		},
+		{
+			testCase: "requires_none_set",
+			groups:   []FlagGroup{Requires("cert", "key")},
+		},
+		{
+			testCase:   "requires_dependency_alone",
+			groups:     []FlagGroup{Requires("cert", "key")},
+			parseInput: []string{"--key", "k"},
+		},
+		{
+			testCase:   "requires_all_set",
+			groups:     []FlagGroup{Requires("cert", "key")},
+			parseInput: []string{"--cert", "c", "--key", "k"},
+		},
+		{
+			testCase:    "requires_dependency_missing",
+			groups:      []FlagGroup{Requires("cert", "key", "token")},
+			parseInput:  []string{"--cert", "c", "--key", "k"},
+			expectedErr: `Flag "cert" requires "key, token", "token" not set`,
+		},
+	}
+
+	for _, test := range tdata {
+		t.Run(test.testCase, func(t *testing.T) {
+			flags := []Flag{
+				&StringFlag{Name: "token", Aliases: []string{"t"}},
+				&StringFlag{Name: "token-file"},
+				&StringFlag{Name: "cert"},
+				&StringFlag{Name: "key"},
+				&BoolFlag{Name: "all"},
+				&StringFlag{Name: "name"},
+			}
+
+			set := flag.NewFlagSet("test", 0)
+			for _, f := range flags {
+				_ = f.Apply(set)
+			}
+			_ = set.Parse(test.parseInput)
+
+			c := &Context{}
+			ctx := NewContext(c.App, set, c)
+			ctx.Command.Flags = flags
+
+			err := checkFlagGroups(test.groups, flags, ctx)
+
+			if test.expectedErr == "" {
+				if err != nil {
+					t.Errorf("did not expected an error, but there was one: %s", err)
+				}
+				return
+			}
+			if err == nil {
+				t.Fatalf("expected error %q, but there was none", test.expectedErr)
+			}
+			expect(t, err.Error(), test.expectedErr)
+		})
+	}
+}
+
+func TestParseArguments(t *testing.T) {
+	arguments := []Argument{
+		{Name: "src", Type: PathArg},
+		{Name: "count", Type: IntArg, Validator: Min(1)},
+		{Name: "mode", Type: EnumArg, Allowed: []string{"fast", "safe"}, Optional: true, Value: "safe"},
+		{Name: "tags", Variadic: true, Optional: true, Max: 2},
+	}
+
+	tdata := []struct {
+		testCase    string
+		input       []string
+		expected    map[string][]string
+		expectedErr string
+	}{
+		{
+			testCase: "required_only",
+			input:    []string{"a.txt", "3"},
+			expected: map[string][]string{"src": {"a.txt"}, "count": {"3"}, "mode": {"safe"}, "tags": {}},
+		},
+		{
+			testCase: "all_set",
+			input:    []string{"a.txt", "3", "fast", "x", "y"},
+			expected: map[string][]string{"src": {"a.txt"}, "count": {"3"}, "mode": {"fast"}, "tags": {"x", "y"}},
+		},
+		{
+			testCase:    "missing_required",
+			input:       []string{"a.txt"},
+			expectedErr: `Required argument "count" not set`,
+		},
+		{
+			testCase:    "invalid_int",
+			input:       []string{"a.txt", "three"},
+			expectedErr: `invalid value "three" for argument count: strconv.ParseInt: parsing "three": invalid syntax`,
+		},
+		{
+			testCase:    "validator",
+			input:       []string{"a.txt", "0"},
+			expectedErr: `invalid value "0" for argument count: must be at least 1`,
+		},
+		{
+			testCase:    "invalid_enum",
+			input:       []string{"a.txt", "3", "slow"},
+			expectedErr: `invalid value "slow" for argument mode: must be one of fast, safe`,
+		},
+		{
+			testCase:    "too_many_variadic",
+			input:       []string{"a.txt", "3", "fast", "x", "y", "z"},
+			expectedErr: `Argument "tags" accepts at most 2 values`,
+		},
+	}
+
+	for _, test := range tdata {
+		t.Run(test.testCase, func(t *testing.T) {
+			parsed, err := parseArguments(arguments, test.input)
+			if test.expectedErr != "" {
+				if err == nil {
+					t.Fatalf("expected error %q, but there was none", test.expectedErr)
+				}
+				expect(t, err.Error(), test.expectedErr)
+				return
+			}
+			expect(t, err, nil)
+			expect(t, parsed, test.expected)
+		})
+	}
+
+	_, err := parseArguments([]Argument{{Name: "src"}}, []string{"a", "b"})
+	expect(t, err.Error(), `Unexpected argument "b"`)
+
+	_, err = parseArguments([]Argument{{Name: "files", Variadic: true, Min: 2}}, []string{"a"})
+	expect(t, err.Error(), `Argument "files" requires at least 2 values`)
+}
+
+func TestContext_Source(t *testing.T) {
+	os.Clearenv()
+	_ = os.Setenv("APP_PORT", "8080")
+
+	set := flag.NewFlagSet("test", 0)
+	flags := []Flag{
+		&StringFlag{Name: "name", Aliases: []string{"n"}},
+		&IntFlag{Name: "port", EnvVars: []string{"APP_HTTP_PORT", "APP_PORT"}},
+		&StringFlag{Name: "token", FilePath: "testdata/missing,context_test.go"},
+		&StringFlag{Name: "region"},
+		&StringFlag{Name: "zone"},
+	}
+	for _, f := range flags {
+		_ = f.Apply(set)
+	}
+	_ = set.Parse([]string{"-n", "gopher"})
+
+	parentCtx := NewContext(&App{Flags: flags}, set, nil)
+	c := NewContext(nil, flag.NewFlagSet("child", 0), parentCtx)
+	c.SetSource("zone", ValueSource{Kind: AltInputSource, Name: "config.yaml", Key: "zone"})
+
+	expect(t, c.Source("n"), ValueSource{Kind: CommandLineSource})
+	expect(t, c.Source("name").String(), "command line")
+	expect(t, c.Source("port"), ValueSource{Kind: EnvVarSource, Name: "APP_PORT"})
+	expect(t, c.Source("port").String(), "$APP_PORT")
+	expect(t, c.Source("token").String(), "file context_test.go")
+	expect(t, c.Source("region"), ValueSource{})
+	expect(t, c.Source("region").String(), "default")
+	expect(t, c.Source("zone").String(), "config.yaml key zone")
+	expect(t, c.Source("unknown"), ValueSource{})
+}
+
+func TestContext_SourceEmptyEnvVar(t *testing.T) {
+	os.Clearenv()
+	_ = os.Setenv("APP_PORT", "")
+
+	var source ValueSource
+	app := &App{
+		Writer: ioutil.Discard,
+		Flags: []Flag{
+			&IntFlag{Name: "port", EnvVars: []string{"APP_PORT"}, Validator: Min(1)},
+		},
+		Action: func(c *Context) error {
+			source = c.Source("port")
+			return nil
+		},
+	}
+
+	expect(t, app.Run([]string{"app"}), nil)
+	expect(t, source, ValueSource{})
+}
@@ -0,0 +1,152 @@
+// Copyright 2020 The vine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// isFlagDeprecated returns whether the Deprecated or ReplacedBy field of the
+// flag is set
+func isFlagDeprecated(f Flag) bool {
+	message, replacement := flagDeprecation(f)
+	return message != "" || replacement != ""
+}
+
+// flagDeprecation returns the Deprecated and ReplacedBy fields of the flag
+func flagDeprecation(f Flag) (message, replacement string) {
+	v := flagValue(f)
+	if v.Kind() != reflect.Struct {
+		return "", ""
+	}
+	if fv := v.FieldByName("Deprecated"); fv.IsValid() {
+		message = fv.String()
+	}
+	if fv := v.FieldByName("ReplacedBy"); fv.IsValid() {
+		replacement = fv.String()
+	}
+	return message, replacement
+}
+
+func (c *Command) isDeprecated() bool {
+	return c.Deprecated != "" || c.ReplacedBy != ""
+}
+
+// deprecationDetails joins the message and the replacement of a deprecated
+// item, i.e. "will be removed in v3, use --new instead"
+func deprecationDetails(message, replacement string) string {
+	var details []string
+	if message != "" {
+		details = append(details, message)
+	}
+	if replacement != "" {
+		details = append(details, "use "+replacement+" instead")
+	}
+	return strings.Join(details, ", ")
+}
+
+// deprecationMarker is appended to the description of deprecated items in
+// the docs
+func deprecationMarker(message, replacement string) string {
+	if details := deprecationDetails(message, replacement); details != "" {
+		return " (deprecated: " + details + ")"
+	}
+	return " (deprecated)"
+}
+
+func flagDeprecationMarker(f Flag) string {
+	message, replacement := flagDeprecation(f)
+	if replacement != "" {
+		replacement = prefixFor(replacement) + replacement
+	}
+	return deprecationMarker(message, replacement)
+}
+
+// deprecationNotice prints a warning about the use of a deprecated item to
+// the error writer of the app, or returns it as an error in strict mode
+func deprecationNotice(a *App, item, message, replacement string) error {
+	notice := item + " is deprecated"
+	if details := deprecationDetails(message, replacement); details != "" {
+		notice += ": " + details
+	}
+
+	if a.StrictDeprecations {
+		return errors.New(notice)
+	}
+	_, _ = fmt.Fprintf(a.errWriter(), "Warning: %s\n", notice)
+	return nil
+}
+
+// checkDeprecatedFlags warns about every deprecated flag which has been set
+// and forwards its value to the replacement flag, unless the replacement is
+// set as well
+func checkDeprecatedFlags(flags []Flag, context *Context) error {
+	for _, f := range flags {
+		if !isFlagDeprecated(f) || flagSource(f, context).Kind == DefaultSource {
+			continue
+		}
+
+		name := f.Names()[0]
+		message, replacement := flagDeprecation(f)
+		var replacementName string
+		if replacement != "" {
+			replacementName = prefixFor(replacement) + replacement
+		}
+		if err := deprecationNotice(context.App, "Flag "+prefixFor(name)+name, message, replacementName); err != nil {
+			return err
+		}
+
+		if replacement != "" {
+			forwardFlag(name, replacement, context)
+		}
+	}
+	return nil
+}
+
+// forwardFlag copies the value of the flag name to every name of the flag
+// replacement, if that one is not set. The replacement may be defined on
+// the command or on any of its ancestors.
+func forwardFlag(name, replacement string, context *Context) {
+	ff := context.flagSet.Lookup(name)
+	set := lookupFlagSet(replacement, context)
+	if ff == nil || set == nil {
+		return
+	}
+
+	names := []string{replacement}
+	if f := lookupFlag(replacement, context); f != nil {
+		names = f.Names()
+	}
+
+	for _, n := range names {
+		if context.IsSet(strings.TrimSpace(n)) {
+			return
+		}
+	}
+	for _, n := range names {
+		copyFlag(strings.TrimSpace(n), ff, set)
+	}
+}
+
+// checkDeprecated warns about the use of the command if it is deprecated
+func (c *Command) checkDeprecated(ctx *Context) error {
+	if !c.isDeprecated() || ctx.shellComplete {
+		return nil
+	}
+	return deprecationNotice(ctx.App, "Command "+c.Name, c.Deprecated, c.ReplacedBy)
+}
@@ -49,8 +49,11 @@ func (a *App) ToMan() (string, error) {
 type cliTemplate struct {
 	App          *App
 	Commands     []string
+	Arguments    []string
 	GlobalArgs   []string
 	SynopsisArgs []string
+	FlagGroups   []string
+	EnvVars      []string
 }
 
 func (a *App) writeDocTemplate(w io.Writer) error {
@@ -59,11 +62,15 @@ func (a *App) writeDocTemplate(w io.Writer) error {
 	if err != nil {
 		return err
 	}
+	a.bindEnvVars()
 	return t.ExecuteTemplate(w, name, &cliTemplate{
 		App:          a,
 		Commands:     prepareCommands(a.Commands, 0),
-		GlobalArgs:   prepareArgsWithValues(a.VisibleFlags()),
+		Arguments:    prepareArguments(a.Arguments),
+		GlobalArgs:   prepareArgsWithValues(documentedFlags(a.Flags)),
 		SynopsisArgs: prepareArgsSynopsis(a.VisibleFlags()),
+		FlagGroups:   prepareFlagGroups(a.FlagGroups),
+		EnvVars:      a.prepareEnvVars(),
 	})
 }
 
@@ -77,6 +84,9 @@ func prepareCommands(commands []*Command, level int) []string {
 		if command.Usage != "" {
 			usage = command.Usage
 		}
+		if command.isDeprecated() {
+			usage = strings.TrimSpace(usage + deprecationMarker(command.Deprecated, command.ReplacedBy))
+		}
 
 		prepared := fmt.Sprintf("%s %s\n\n%s\n",
 			strings.Repeat("#", level+2),
@@ -84,11 +94,21 @@ func prepareCommands(commands []*Command, level int) []string {
 			usage,
 		)
 
+		arguments := prepareArguments(command.Arguments)
+		if len(arguments) > 0 {
+			prepared += fmt.Sprintf("\n%s", strings.Join(arguments, "\n"))
+		}
+
 		flags := prepareArgsWithValues(command.Flags)
 		if len(flags) > 0 {
 			prepared += fmt.Sprintf("\n%s", strings.Join(flags, "\n"))
 		}
 
+		groups := prepareFlagGroups(command.FlagGroups)
+		if len(groups) > 0 {
+			prepared += fmt.Sprintf("\n%s", strings.Join(groups, "\n"))
+		}
+
 		coms = append(coms, prepared)
 
 		// recursevly iterate subcommands
@@ -100,6 +120,24 @@ func prepareCommands(commands []*Command, level int) []string {
 	return coms
 }
 
+func prepareArguments(arguments []Argument) []string {
+	var prepared []string
+	for _, a := range arguments {
+		// escape the angle brackets of the required arguments from markdown
+		synopsis := strings.NewReplacer("<", `\<`, ">", `\>`).Replace(a.Synopsis())
+		prepared = append(prepared, fmt.Sprintf("**%s**: %s\n", synopsis, a.description()))
+	}
+	return prepared
+}
+
+func prepareFlagGroups(groups []FlagGroup) []string {
+	var prepared []string
+	for _, g := range groups {
+		prepared = append(prepared, fmt.Sprintf("**%s**: %s\n", g.Synopsis(), g.Description()))
+	}
+	return prepared
+}
+
 func prepareArgsWithValues(flags []Flag) []string {
 	return prepareFlags(flags, ", ", "**", "**", `""`, true)
 }
@@ -121,7 +159,7 @@ func prepareFlags(
 		}
 		modifiedArg := opener
 
-		for _, s := range flag.Names() {
+		for _, s := range helpNames(flag) {
 			trimmed := strings.TrimSpace(s)
 			if len(modifiedArg) > len(opener) {
 				modifiedArg += sep
@@ -151,9 +189,15 @@ func prepareFlags(
 // flagDetails returns a string containing the flags metadata
 func flagDetails(flag DocGenerationFlag) string {
 	description := flag.GetUsage()
+	if cf, ok := flag.(ChoiceFlag); ok {
+		description += " (one of: " + strings.Join(cf.GetAllowed(), ", ") + ")"
+	}
 	value := flag.GetValue()
 	if value != "" {
 		description += " (default: " + value + ")"
 	}
+	if isFlagDeprecated(flag) {
+		description += flagDeprecationMarker(flag)
+	}
 	return ": " + description
 }
@@ -3,6 +3,7 @@ package cli
 import (
 	"bytes"
 	"io/ioutil"
+	"strings"
 	"testing"
 )
 
@@ -110,6 +111,67 @@ func TestToMarkdownNoFlags(t *testing.T) {
 	expectFileContent(t, "testdata/expected-doc-no-flags.md", res)
 }
 
+func TestToMarkdownFlagGroups(t *testing.T) {
+	// Given
+	app := testApp()
+	app.FlagGroups = []FlagGroup{MutuallyExclusive("socket", "flag")}
+	app.Commands[0].FlagGroups = []FlagGroup{
+		RequiredTogether("flag", "another-flag"),
+		Requires("another-flag", "flag"),
+	}
+
+	// When
+	res, err := app.ToMarkdown()
+
+	// Then
+	expect(t, err, nil)
+	expectFileContent(t, "testdata/expected-doc-flag-groups.md", res)
+}
+
+func TestToMarkdownDeprecated(t *testing.T) {
+	// Given
+	app := testApp()
+	app.Flags = append(app.Flags, &StringFlag{Name: "old-flag", ReplacedBy: "flag"})
+	app.Commands[1].Deprecated = "will be removed in v3"
+
+	// When
+	res, err := app.ToMarkdown()
+
+	// Then
+	expect(t, err, nil)
+	expectFileContent(t, "testdata/expected-doc-deprecated.md", res)
+}
+
+func TestToMarkdownArguments(t *testing.T) {
+	// Given
+	app := testApp()
+	app.Arguments = []Argument{{Name: "target", Usage: "the target"}}
+	app.Commands[1].Arguments = []Argument{
+		{Name: "topic", Type: EnumArg, Allowed: []string{"cpu", "mem"}},
+		{Name: "extra", Variadic: true, Optional: true},
+	}
+
+	// When
+	res, err := app.ToMarkdown()
+
+	// Then
+	expect(t, err, nil)
+	expectFileContent(t, "testdata/expected-doc-arguments.md", res)
+}
+
+func TestToMarkdownEnvPrefix(t *testing.T) {
+	// Given
+	app := testApp()
+	app.EnvPrefix = "GREET"
+
+	// When
+	res, err := app.ToMarkdown()
+
+	// Then
+	expect(t, err, nil)
+	expectFileContent(t, "testdata/expected-doc-env-prefix.md", res)
+}
+
 func TestToMarkdownNoCommands(t *testing.T) {
 	// Given
 	app := testApp()
@@ -147,3 +209,18 @@ func TestToMan(t *testing.T) {
 	expect(t, err, nil)
 	expectFileContent(t, "testdata/expected-doc-full.man", res)
 }
+
+func TestToMarkdown_EnumFlag(t *testing.T) {
+	// Given
+	app := testApp()
+	app.Flags = []Flag{
+		&EnumFlag{Name: "format", Allowed: []string{"json", "yaml"}, Usage: "output format", Value: "json"},
+	}
+
+	// When
+	res, err := app.ToMarkdown()
+
+	// Then
+	expect(t, err, nil)
+	expect(t, strings.Contains(res, "**--format**=\"\": output format (one of: json, yaml) (default: json)"), true)
+}
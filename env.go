@@ -0,0 +1,120 @@
+// Copyright 2020 The vine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cli
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"unicode"
+)
+
+// envVarName joins the prefix and the name with "_", upper cased, every
+// character other than a letter or a digit being replaced with "_", i.e.
+// "MYAPP" and "db-host" give "MYAPP_DB_HOST"
+func envVarName(prefix, name string) string {
+	mangled := strings.Map(func(r rune) rune {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			return unicode.ToUpper(r)
+		}
+		return '_'
+	}, name)
+	if prefix == "" {
+		return mangled
+	}
+	return prefix + "_" + mangled
+}
+
+// bindEnvVars binds the flags of the app and of its commands to the
+// environment variables derived from EnvPrefix
+func (a *App) bindEnvVars() {
+	bindFlagEnvVars(a.Flags, a.EnvPrefix)
+	bindCommandEnvVars(a.Commands, a.EnvPrefix)
+}
+
+func bindCommandEnvVars(commands []*Command, prefix string) {
+	for _, c := range commands {
+		commandPrefix := c.EnvPrefix
+		if commandPrefix == "" && prefix != "" {
+			commandPrefix = EnvVarNamer(prefix, c.Name)
+		}
+		bindFlagEnvVars(c.Flags, commandPrefix)
+		bindCommandEnvVars(c.Subcommands, commandPrefix)
+	}
+}
+
+// bindFlagEnvVars sets the EnvVars of every flag which has none and does not
+// opt out with NoAutoEnv
+func bindFlagEnvVars(flags []Flag, prefix string) {
+	if prefix == "" {
+		return
+	}
+	for _, f := range flags {
+		if isBuiltinFlag(f) {
+			continue
+		}
+		v := flagValue(f)
+		if v.Kind() != reflect.Struct {
+			continue
+		}
+		envVars := v.FieldByName("EnvVars")
+		if !envVars.IsValid() || !envVars.CanSet() || envVars.Len() > 0 {
+			continue
+		}
+		if noAutoEnv := v.FieldByName("NoAutoEnv"); noAutoEnv.IsValid() && noAutoEnv.Bool() {
+			continue
+		}
+		envVars.Set(reflect.ValueOf([]string{EnvVarNamer(prefix, f.Names()[0])}))
+	}
+}
+
+// prepareEnvVars lists the environment variables of the flags of the app and
+// of its commands for the docs
+func (a *App) prepareEnvVars() []string {
+	var prepared []string
+	prepared = append(prepared, prepareFlagEnvVars(a.Flags, "")...)
+	return append(prepared, prepareCommandEnvVars(a.Commands, "")...)
+}
+
+func prepareCommandEnvVars(commands []*Command, path string) []string {
+	var prepared []string
+	for _, c := range commands {
+		if c.Hidden {
+			continue
+		}
+		commandPath := strings.TrimSpace(path + " " + c.Name)
+		prepared = append(prepared, prepareFlagEnvVars(c.Flags, commandPath)...)
+		prepared = append(prepared, prepareCommandEnvVars(c.Subcommands, commandPath)...)
+	}
+	return prepared
+}
+
+func prepareFlagEnvVars(flags []Flag, commandPath string) []string {
+	var prepared []string
+	for _, f := range documentedFlags(flags) {
+		name := f.Names()[0]
+		usage := prefixFor(name) + name
+		if commandPath != "" {
+			usage = commandPath + " " + usage
+		}
+		if df, ok := f.(DocGenerationFlag); ok && df.GetUsage() != "" {
+			usage = df.GetUsage() + " (" + usage + ")"
+		}
+		for _, envVar := range flagStringSliceField(f, "EnvVars") {
+			prepared = append(prepared, fmt.Sprintf("**%s**: %s\n", strings.TrimSpace(envVar), usage))
+		}
+	}
+	return prepared
+}
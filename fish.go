@@ -44,6 +44,7 @@ func (a *App) writeFishCompletionTemplate(w io.Writer) error {
 	if err != nil {
 		return err
 	}
+	a.bindEnvVars()
 	allCommands := []string{}
 
 	// Add global flags
@@ -65,6 +66,12 @@ func (a *App) writeFishCompletionTemplate(w io.Writer) error {
 		)
 	}
 
+	// Add the values of the arguments
+	completions = append(
+		completions,
+		a.prepareFishArguments(a.Arguments, allCommands)...,
+	)
+
 	// Add commands and their flags
 	completions = append(
 		completions,
@@ -81,7 +88,7 @@ func (a *App) writeFishCompletionTemplate(w io.Writer) error {
 func (a *App) prepareFishCommands(commands []*Command, allCommands *[]string, previousCommands []string) []string {
 	completions := []string{}
 	for _, command := range commands {
-		if command.Hidden {
+		if command.Hidden || command.isDeprecated() {
 			continue
 		}
 
@@ -111,6 +118,10 @@ func (a *App) prepareFishCommands(commands []*Command, allCommands *[]string, pr
 			completions,
 			a.prepareFishFlags(command.Flags, command.Names())...,
 		)
+		completions = append(
+			completions,
+			a.prepareFishArguments(command.Arguments, command.Names())...,
+		)
 
 		// recursevly iterate subcommands
 		if len(command.Subcommands) > 0 {
@@ -130,7 +141,7 @@ func (a *App) prepareFishFlags(flags []Flag, previousCommands []string) []string
 	completions := []string{}
 	for _, f := range flags {
 		flag, ok := f.(DocGenerationFlag)
-		if !ok {
+		if !ok || isFlagDeprecated(f) {
 			continue
 		}
 
@@ -156,10 +167,21 @@ func (a *App) prepareFishFlags(flags []Flag, previousCommands []string) []string
 			}
 		}
 
+		if nf, ok := f.(negatableFlag); ok {
+			for _, opt := range nf.negatedNames() {
+				completion.WriteString(fmt.Sprintf(" -l %s", opt))
+			}
+		}
+
 		if flag.TakesValue() {
 			completion.WriteString(" -r")
 		}
 
+		if cf, ok := f.(ChoiceFlag); ok {
+			completion.WriteString(fmt.Sprintf(" -a '%s'",
+				escapeSingleQuotes(strings.Join(cf.GetAllowed(), " "))))
+		}
+
 		if flag.GetUsage() != "" {
 			completion.WriteString(fmt.Sprintf(" -d '%s'",
 				escapeSingleQuotes(flag.GetUsage())))
@@ -171,6 +193,32 @@ func (a *App) prepareFishFlags(flags []Flag, previousCommands []string) []string
 	return completions
 }
 
+func (a *App) prepareFishArguments(arguments []Argument, previousCommands []string) []string {
+	completions := []string{}
+	for _, arg := range arguments {
+		if arg.Type != EnumArg {
+			continue
+		}
+
+		completion := &strings.Builder{}
+		completion.WriteString(fmt.Sprintf(
+			"complete -c %s -n '%s' -f -a '%s'",
+			a.Name,
+			a.fishSubcommandHelper(previousCommands),
+			escapeSingleQuotes(strings.Join(arg.Allowed, " ")),
+		))
+
+		if arg.Usage != "" {
+			completion.WriteString(fmt.Sprintf(" -d '%s'",
+				escapeSingleQuotes(arg.Usage)))
+		}
+
+		completions = append(completions, completion.String())
+	}
+
+	return completions
+}
+
 func fishAddFileFlag(flag Flag, completion *strings.Builder) {
 	switch f := flag.(type) {
 	case *GenericFlag:
@@ -21,9 +21,9 @@ import (
 	"reflect"
 	"regexp"
 	"runtime"
+	"sort"
 	"strconv"
 	"strings"
-	"syscall"
 	"time"
 )
 
@@ -72,6 +72,11 @@ var FlagNamePrefixer FlagNamePrefixFunc = prefixedNames
 // details. This is used by the default FlagStringer.
 var FlagEnvHinter FlagEnvHintFunc = withEnvHint
 
+// EnvVarNamer derives the environment variables bound to the flags of an
+// App with an EnvPrefix. This is used to name both the prefix of a command
+// and the variable of a flag.
+var EnvVarNamer EnvVarNameFunc = envVarName
+
 // FlagFileHinter annotates flag help message with the environment variable
 // details. This is used by the default FlagStringer.
 var FlagFileHinter FlagFileHintFunc = withFileHint
@@ -115,6 +120,22 @@ type RequiredFlag interface {
 	IsRequired() bool
 }
 
+// negatableFlag is implemented by flags which accept a --no-<name> form
+type negatableFlag interface {
+	Flag
+
+	negatedNames() []string
+}
+
+// ChoiceFlag is an interface for flags restricted to a fixed set of values,
+// which are offered in help, documentation and shell completion
+type ChoiceFlag interface {
+	Flag
+
+	// GetAllowed returns the values accepted by the flag
+	GetAllowed() []string
+}
+
 // DocGenerationFlag is an interface that allows documentation generation for the flag
 type DocGenerationFlag interface {
 	Flag
@@ -135,7 +156,7 @@ func flagSet(name string, flags []Flag) (*flag.FlagSet, error) {
 
 	for _, f := range flags {
 		if err := f.Apply(set); err != nil {
-			return nil, err
+			return nil, fmt.Errorf("%w (from %s)", err, envOrFileSource(f))
 		}
 	}
 	set.SetOutput(ioutil.Discard)
@@ -144,13 +165,25 @@ func flagSet(name string, flags []Flag) (*flag.FlagSet, error) {
 
 func visibleFlags(fl []Flag) []Flag {
 	var visible []Flag
+	for _, f := range documentedFlags(fl) {
+		if !isFlagDeprecated(f) {
+			visible = append(visible, f)
+		}
+	}
+	return visible
+}
+
+// documentedFlags returns the flags with Hidden=false, including the
+// deprecated ones
+func documentedFlags(fl []Flag) []Flag {
+	var documented []Flag
 	for _, f := range fl {
 		field := flagValue(f).FieldByName("Hidden")
 		if !field.IsValid() || !field.Bool() {
-			visible = append(visible, f)
+			documented = append(documented, f)
 		}
 	}
-	return visible
+	return documented
 }
 
 func prefixFor(name string) (prefix string) {
@@ -225,6 +258,24 @@ func flagNames(name string, aliases []string) []string {
 	return ret
 }
 
+// helpNames returns the names of the flag as shown in help and docs, a
+// negatable long name is shown as "[no-]name"
+func helpNames(f Flag) []string {
+	names := f.Names()
+	if nf, ok := f.(negatableFlag); !ok || len(nf.negatedNames()) == 0 {
+		return names
+	}
+
+	ret := make([]string, len(names))
+	for i, name := range names {
+		if len(name) > 1 {
+			name = "[no-]" + name
+		}
+		ret[i] = name
+	}
+	return ret
+}
+
 func flagStringSliceField(f Flag, name string) []string {
 	fv := flagValue(f)
 	field := fv.FieldByName(name)
@@ -268,6 +319,39 @@ func stringifyFlag(f Flag) string {
 	case *StringSliceFlag:
 		return withEnvHint(flagStringSliceField(f, "EnvVars"),
 			stringifyStringSliceFlag(f))
+	case *EnumSliceFlag:
+		return withEnvHint(flagStringSliceField(f, "EnvVars"),
+			stringifyEnumSliceFlag(f))
+	case *StringMapFlag:
+		return withEnvHint(flagStringSliceField(f, "EnvVars"),
+			stringifyStringMapFlag(f))
+	case *ByteSizeFlag:
+		return withEnvHint(flagStringSliceField(f, "EnvVars"),
+			stringifyByteSizeFlag(f))
+	case *IPFlag:
+		return withEnvHint(flagStringSliceField(f, "EnvVars"),
+			stringifyValueFlag(f.Usage, "ip", f.Names(), f.GetValue(), f.DefaultText))
+	case *IPNetFlag:
+		return withEnvHint(flagStringSliceField(f, "EnvVars"),
+			stringifyValueFlag(f.Usage, "cidr", f.Names(), f.GetValue(), f.DefaultText))
+	case *HostPortFlag:
+		return withEnvHint(flagStringSliceField(f, "EnvVars"),
+			stringifyValueFlag(f.Usage, "host:port", f.Names(), f.GetValue(), f.DefaultText))
+	case *URLFlag:
+		return withEnvHint(flagStringSliceField(f, "EnvVars"),
+			stringifyValueFlag(f.Usage, "url", f.Names(), f.GetValue(), f.DefaultText))
+	case *IPSliceFlag:
+		return withEnvHint(flagStringSliceField(f, "EnvVars"),
+			stringifySliceFlag(f.Usage, "ip", f.Names(), quotedSliceValues(f.Value)))
+	case *IPNetSliceFlag:
+		return withEnvHint(flagStringSliceField(f, "EnvVars"),
+			stringifySliceFlag(f.Usage, "cidr", f.Names(), quotedSliceValues(f.Value)))
+	case *HostPortSliceFlag:
+		return withEnvHint(flagStringSliceField(f, "EnvVars"),
+			stringifySliceFlag(f.Usage, "host:port", f.Names(), quotedSliceValues(f.Value)))
+	case *URLSliceFlag:
+		return withEnvHint(flagStringSliceField(f, "EnvVars"),
+			stringifySliceFlag(f.Usage, "url", f.Names(), quotedSliceValues(f.Value)))
 	}
 
 	placeholder, usage := unquoteUsage(fv.FieldByName("Usage").String())
@@ -290,10 +374,18 @@ func stringifyFlag(f Flag) string {
 		defaultValueString = fmt.Sprintf(" (default: %s)", helpText.String())
 	}
 
+	if df, ok := f.(DocGenerationFlag); ok && !df.TakesValue() {
+		needsPlaceholder = false
+	}
+
 	if defaultValueString == " (default: )" {
 		defaultValueString = ""
 	}
 
+	if cf, ok := f.(ChoiceFlag); ok && needsPlaceholder && placeholder == "" {
+		placeholder = strings.Join(cf.GetAllowed(), "|")
+	}
+
 	if needsPlaceholder && placeholder == "" {
 		placeholder = fv.FieldByName("Value").Kind().String()
 	}
@@ -301,7 +393,7 @@ func stringifyFlag(f Flag) string {
 	usageWithDefault := strings.TrimSpace(usage + defaultValueString)
 
 	return withEnvHint(flagStringSliceField(f, "EnvVars"),
-		fmt.Sprintf("%s\t%s", prefixedNames(f.Names(), placeholder), usageWithDefault))
+		fmt.Sprintf("%s\t%s", prefixedNames(helpNames(f), placeholder), usageWithDefault))
 }
 
 func stringifyIntSliceFlag(f *IntSliceFlag) string {
@@ -351,6 +443,65 @@ func stringifyStringSliceFlag(f *StringSliceFlag) string {
 	return stringifySliceFlag(f.Usage, "strings", f.Names(), defaultVals)
 }
 
+func stringifyEnumSliceFlag(f *EnumSliceFlag) string {
+	var defaultVals []string
+	if f.Value != nil && len(f.Value.Value()) > 0 {
+		for _, s := range f.Value.Value() {
+			defaultVals = append(defaultVals, strconv.Quote(s))
+		}
+	}
+
+	return stringifySliceFlag(f.Usage, strings.Join(f.Allowed, "|"), f.Names(), defaultVals)
+}
+
+func stringifyStringMapFlag(f *StringMapFlag) string {
+	sep := f.Separator
+	if sep == "" {
+		sep = defaultMapSeparator
+	}
+
+	var defaultVals []string
+	if f.Value != nil && len(f.Value.Value()) > 0 {
+		for k, v := range f.Value.Value() {
+			defaultVals = append(defaultVals, strconv.Quote(k+sep+v))
+		}
+		sort.Strings(defaultVals)
+	}
+
+	return stringifySliceFlag(f.Usage, "key"+sep+"value", f.Names(), defaultVals)
+}
+
+func stringifyByteSizeFlag(f *ByteSizeFlag) string {
+	return stringifyValueFlag(f.Usage, "size", f.Names(), formatByteSize(f.Value), f.DefaultText)
+}
+
+func quotedSliceValues(value *StringSlice) []string {
+	var defaultVals []string
+	if value != nil {
+		for _, s := range value.Value() {
+			defaultVals = append(defaultVals, strconv.Quote(s))
+		}
+	}
+	return defaultVals
+}
+
+func stringifyValueFlag(usage, defaultPlaceholder string, names []string, defaultVal, defaultText string) string {
+	placeholder, usage := unquoteUsage(usage)
+	if placeholder == "" {
+		placeholder = defaultPlaceholder
+	}
+
+	defaultValueString := ""
+	if defaultText != "" {
+		defaultValueString = fmt.Sprintf(" (default: %s)", defaultText)
+	} else if defaultVal != "" {
+		defaultValueString = fmt.Sprintf(" (default: %s)", defaultVal)
+	}
+
+	usageWithDefault := strings.TrimSpace(usage + defaultValueString)
+	return fmt.Sprintf("%s\t%s", prefixedNames(names, placeholder), usageWithDefault)
+}
+
 func stringifySliceFlag(usage, defaultPlaceholder string, names, defaultVals []string) string {
 	placeholder, usage := unquoteUsage(usage)
 	if placeholder == "" {
@@ -377,16 +528,6 @@ func hasFlag(flags []Flag, fl Flag) bool {
 }
 
 func flagFromEnvOrFile(envVars []string, filePath string) (val string, ok bool) {
-	for _, envVar := range envVars {
-		envVar = strings.TrimSpace(envVar)
-		if val, ok := syscall.Getenv(envVar); ok {
-			return val, true
-		}
-	}
-	for _, fileVar := range strings.Split(filePath, ",") {
-		if data, err := ioutil.ReadFile(fileVar); err == nil {
-			return string(data), true
-		}
-	}
-	return "", false
+	val, _, ok = flagFromEnvOrFileSource(envVars, filePath)
+	return val, ok
 }
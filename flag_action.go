@@ -0,0 +1,54 @@
+// Copyright 2020 The vine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cli
+
+// flagAction returns the Action of the flag, if any
+func flagAction(f Flag) FlagActionFunc {
+	fv := flagValue(f).FieldByName("Action")
+	if !fv.IsValid() || fv.IsNil() {
+		return nil
+	}
+	action, _ := fv.Interface().(FlagActionFunc)
+	return action
+}
+
+// RunFlagAction calls the Action of the flag, if any, with its value in the
+// context
+func RunFlagAction(c *Context, f Flag) error {
+	action := flagAction(f)
+	if action == nil {
+		return nil
+	}
+
+	fl := lookupContextFlag(f.Names()[0], c)
+	if fl == nil {
+		return nil
+	}
+	return action(c, flagGetValue(fl.Value))
+}
+
+// runFlagActions calls, in order, the Action of every flag set on the
+// command line, through the environment or from files
+func runFlagActions(flags []Flag, context *Context) error {
+	for _, f := range flags {
+		if flagAction(f) == nil || flagSource(f, context).Kind == DefaultSource {
+			continue
+		}
+		if err := RunFlagAction(context, f); err != nil {
+			return err
+		}
+	}
+	return nil
+}
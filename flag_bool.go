@@ -33,6 +33,15 @@ type BoolFlag struct {
 	DefaultText string
 	Destination *bool
 	HasBeenSet  bool
+	Validator   ValidatorFunc
+	Deprecated  string
+	ReplacedBy  string
+	Action      FlagActionFunc
+	NoAutoEnv   bool
+	Persistent  bool
+	// Negatable registers a --no-<name> form for every long name of the
+	// flag, which sets the flag to false
+	Negatable bool
 }
 
 // IsSet returns whether or not the flag has been set through env or file
@@ -95,9 +104,66 @@ func (f *BoolFlag) Apply(set *flag.FlagSet) error {
 		set.Bool(name, f.Value, f.Usage)
 	}
 
+	for i, name := range f.negatedNames() {
+		if set.Lookup(name) != nil {
+			continue
+		}
+		set.Var(&boolNegation{set: set, name: f.longNames()[i]}, name, f.Usage)
+	}
+
 	return nil
 }
 
+// negatedNames returns the --no-<name> forms of the flag, which are empty
+// unless the flag is Negatable
+func (f *BoolFlag) negatedNames() []string {
+	if !f.Negatable {
+		return nil
+	}
+	var names []string
+	for _, name := range f.longNames() {
+		names = append(names, "no-"+name)
+	}
+	return names
+}
+
+func (f *BoolFlag) longNames() []string {
+	var names []string
+	for _, name := range f.Names() {
+		if len(name) > 1 {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+// boolNegation is the flag.Value of a --no-<name> flag, it sets the
+// negated flag to the opposite value
+type boolNegation struct {
+	set  *flag.FlagSet
+	name string
+}
+
+func (b *boolNegation) Set(value string) error {
+	v, err := strconv.ParseBool(value)
+	if err != nil {
+		return err
+	}
+	return b.set.Set(b.name, strconv.FormatBool(!v))
+}
+
+func (b *boolNegation) String() string {
+	return "false"
+}
+
+func (b *boolNegation) Get() interface{} {
+	return false
+}
+
+func (b *boolNegation) IsBoolFlag() bool {
+	return true
+}
+
 func (a *App) boolVar(p *bool, name, alias string, value bool, usage, env string) {
 	if a.Flags == nil {
 		a.Flags = make([]Flag, 0)
@@ -157,12 +223,22 @@ func (a *App) BoolP(name, alias string, value bool, usage, env string) *bool {
 // Bool looks up the value of a local BoolFlag, returns
 // false if not found
 func (c *Context) Bool(name string) bool {
-	if fs := lookupFlagSet(name, c); fs != nil {
+	if fs := c.strictFlagSet(name); fs != nil {
 		return lookupBool(name, fs)
 	}
 	return false
 }
 
+// GetBool looks up the value of a BoolFlag, returns an error if the
+// flag is not defined
+func (c *Context) GetBool(name string) (bool, error) {
+	fs, err := c.definedFlagSet(name)
+	if err != nil {
+		return false, err
+	}
+	return lookupBool(name, fs), nil
+}
+
 func lookupBool(name string, set *flag.FlagSet) bool {
 	f := set.Lookup(name)
 	if f != nil {
@@ -0,0 +1,324 @@
+// Copyright 2020 The vine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cli
+
+import (
+	"flag"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+type byteUnit struct {
+	suffix string
+	size   uint64
+}
+
+// byteUnits lists the SI and IEC units from the largest to the smallest
+var byteUnits = []byteUnit{
+	{"EiB", 1 << 60},
+	{"EB", 1e18},
+	{"PiB", 1 << 50},
+	{"PB", 1e15},
+	{"TiB", 1 << 40},
+	{"TB", 1e12},
+	{"GiB", 1 << 30},
+	{"GB", 1e9},
+	{"MiB", 1 << 20},
+	{"MB", 1e6},
+	{"KiB", 1 << 10},
+	{"KB", 1e3},
+}
+
+// parseByteSize parses a size such as "512K", "10MiB", "1.5GB" or "4096".
+// The single letter units, and the units ending in "B", are SI (powers of
+// 1000), the units ending in "i" or "iB" are IEC (powers of 1024). Units are
+// case insensitive.
+func parseByteSize(s string) (uint64, error) {
+	s = strings.TrimSpace(s)
+	i := strings.IndexFunc(s, func(r rune) bool {
+		return (r < '0' || r > '9') && r != '.'
+	})
+	if i < 0 {
+		i = len(s)
+	}
+	number, unit := s[:i], strings.TrimSpace(s[i:])
+	if number == "" {
+		return 0, fmt.Errorf("invalid byte size %q", s)
+	}
+
+	multiplier, ok := byteMultiplier(unit)
+	if !ok {
+		return 0, fmt.Errorf("invalid byte size %q: unknown unit %q", s, unit)
+	}
+
+	if !strings.Contains(number, ".") {
+		n, err := strconv.ParseUint(number, 10, 64)
+		if err != nil {
+			return 0, fmt.Errorf("invalid byte size %q", s)
+		}
+		if n > math.MaxUint64/multiplier {
+			return 0, fmt.Errorf("byte size %q out of range", s)
+		}
+		return n * multiplier, nil
+	}
+
+	f, err := strconv.ParseFloat(number, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid byte size %q", s)
+	}
+	f *= float64(multiplier)
+	if f >= math.MaxUint64 {
+		return 0, fmt.Errorf("byte size %q out of range", s)
+	}
+	return uint64(f), nil
+}
+
+func byteMultiplier(unit string) (uint64, bool) {
+	u := strings.ToUpper(unit)
+	if u == "" || u == "B" {
+		return 1, true
+	}
+	u = strings.TrimSuffix(u, "B")
+	for _, bu := range byteUnits {
+		if strings.ToUpper(strings.TrimSuffix(bu.suffix, "B")) == u {
+			return bu.size, true
+		}
+	}
+	return 0, false
+}
+
+// formatByteSize returns a human readable representation of n, exact in the
+// largest unit dividing it or otherwise rounded to one decimal IEC unit
+func formatByteSize(n uint64) string {
+	for _, bu := range byteUnits {
+		if n >= bu.size && n%bu.size == 0 {
+			return fmt.Sprintf("%d%s", n/bu.size, bu.suffix)
+		}
+	}
+
+	for _, bu := range byteUnits {
+		if n >= bu.size && strings.HasSuffix(bu.suffix, "iB") {
+			return strconv.FormatFloat(float64(n)/float64(bu.size), 'f', 1, 64) + bu.suffix
+		}
+	}
+
+	return fmt.Sprintf("%dB", n)
+}
+
+// byteSize wraps a uint64 to satisfy flag.Value, accepting human readable
+// sizes
+type byteSize struct {
+	value *uint64
+}
+
+func newByteSize(value uint64, p *uint64) *byteSize {
+	if p == nil {
+		p = new(uint64)
+	}
+	*p = value
+	return &byteSize{value: p}
+}
+
+// Set parses a size with an optional unit
+func (b *byteSize) Set(value string) error {
+	n, err := parseByteSize(value)
+	if err != nil {
+		return err
+	}
+	*b.value = n
+	return nil
+}
+
+// String returns a readable representation of this value
+func (b *byteSize) String() string {
+	if b.value == nil {
+		return formatByteSize(0)
+	}
+	return formatByteSize(*b.value)
+}
+
+// Get returns the number of bytes
+func (b *byteSize) Get() interface{} {
+	return *b.value
+}
+
+// ByteSizeFlag is a flag with type uint64 which accepts human readable
+// sizes, i.e. 512K, 10MiB or 1.5GB
+type ByteSizeFlag struct {
+	Name          string
+	Aliases       []string
+	Usage         string
+	EnvVars       []string
+	FilePath      string
+	Required      bool
+	Hidden        bool
+	Value         uint64
+	DefaultText   string
+	Destination   *uint64
+	HasBeenSet    bool
+	Validator     ValidatorFunc
+	Deprecated    string
+	ReplacedBy    string
+	Action        FlagActionFunc
+	NoAutoEnv     bool
+	ValueFromFile bool
+	Persistent    bool
+}
+
+// IsSet returns whether or not the flag has been set through env or file
+func (f *ByteSizeFlag) IsSet() bool {
+	return f.HasBeenSet
+}
+
+// String returns a readable representation of this value
+// (for usage defaults)
+func (f *ByteSizeFlag) String() string {
+	return FlagStringer(f)
+}
+
+// Names returns the names of the flag
+func (f *ByteSizeFlag) Names() []string {
+	return flagNames(f.Name, f.Aliases)
+}
+
+// IsRequired returns whether or not the flag is required
+func (f *ByteSizeFlag) IsRequired() bool {
+	return f.Required
+}
+
+// TakesValue returns true of the flag takes a value, otherwise false
+func (f *ByteSizeFlag) TakesValue() bool {
+	return true
+}
+
+// GetUsage returns the usage string for the flag
+func (f *ByteSizeFlag) GetUsage() string {
+	return f.Usage
+}
+
+// GetValue returns the flags value as string representation and an empty
+// string if the flag takes no value at all.
+func (f *ByteSizeFlag) GetValue() string {
+	return formatByteSize(f.Value)
+}
+
+// Apply populates the flag given the flag set and environment
+func (f *ByteSizeFlag) Apply(set *flag.FlagSet) error {
+	if val, ok := flagFromEnvOrFile(f.EnvVars, f.FilePath); ok {
+		if val != "" {
+			valSize, err := parseByteSize(val)
+			if err != nil {
+				return fmt.Errorf("could not parse %q as byte size value for flag %s: %s", val, f.Name, err)
+			}
+
+			f.Value = valSize
+			f.HasBeenSet = true
+		}
+	}
+
+	value := newByteSize(f.Value, f.Destination)
+	for _, name := range f.Names() {
+		set.Var(value, name, f.Usage)
+	}
+
+	return nil
+}
+
+func (a *App) byteSizeVar(p *uint64, name, alias string, value uint64, usage, env string) {
+	if a.Flags == nil {
+		a.Flags = make([]Flag, 0)
+	}
+	flag := &ByteSizeFlag{
+		Name:        name,
+		Usage:       usage,
+		Value:       value,
+		Destination: p,
+	}
+	if alias != "" {
+		flag.Aliases = []string{alias}
+	}
+	if env != "" {
+		flag.EnvVars = []string{env}
+	}
+	a.Flags = append(a.Flags, flag)
+}
+
+// ByteSizeVar defines a byte size flag with specified name, default value, usage string and env string.
+// The argument p points to a uint64 variable in which to store the value of the flag.
+func (a *App) ByteSizeVar(p *uint64, name string, value uint64, usage, env string) {
+	a.byteSizeVar(p, name, "", value, usage, env)
+}
+
+// ByteSizeVarP is like ByteSizeVar, but accepts a shorthand letter that can be used after a single dash.
+func (a *App) ByteSizeVarP(p *uint64, name, alias string, value uint64, usage, env string) {
+	a.byteSizeVar(p, name, alias, value, usage, env)
+}
+
+// ByteSizeVar defines a byte size flag with specified name, default value, usage string and env string.
+// The argument p points to a uint64 variable in which to store the value of the flag.
+func ByteSizeVar(p *uint64, name string, value uint64, usage, env string) {
+	CommandLine.ByteSizeVar(p, name, value, usage, env)
+}
+
+// ByteSizeVarP is like ByteSizeVar, but accepts a shorthand letter that can be used after a single dash.
+func ByteSizeVarP(p *uint64, name, alias string, value uint64, usage, env string) {
+	CommandLine.ByteSizeVarP(p, name, alias, value, usage, env)
+}
+
+// ByteSize defines a byte size flag with specified name, default value, usage string and env string.
+// The return value is the address of a uint64 variable that stores the value of the flag.
+func (a *App) ByteSize(name string, value uint64, usage, env string) *uint64 {
+	p := new(uint64)
+	a.ByteSizeVar(p, name, value, usage, env)
+	return p
+}
+
+// ByteSizeP is like ByteSize, but accepts a shorthand letter that can be used after a single dash.
+func (a *App) ByteSizeP(name, alias string, value uint64, usage, env string) *uint64 {
+	p := new(uint64)
+	a.ByteSizeVarP(p, name, alias, value, usage, env)
+	return p
+}
+
+// ByteSize looks up the value of a local ByteSizeFlag, returns
+// 0 if not found
+func (c *Context) ByteSize(name string) uint64 {
+	if fs := c.strictFlagSet(name); fs != nil {
+		return lookupByteSize(name, fs)
+	}
+	return 0
+}
+
+// GetByteSize looks up the value of a ByteSizeFlag, returns an error if the
+// flag is not defined
+func (c *Context) GetByteSize(name string) (uint64, error) {
+	fs, err := c.definedFlagSet(name)
+	if err != nil {
+		return 0, err
+	}
+	return lookupByteSize(name, fs), nil
+}
+
+func lookupByteSize(name string, set *flag.FlagSet) uint64 {
+	f := set.Lookup(name)
+	if f != nil {
+		if value, ok := f.Value.(*byteSize); ok {
+			return *value.value
+		}
+	}
+	return 0
+}
@@ -0,0 +1,249 @@
+// Copyright 2020 The vine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cli
+
+import (
+	"flag"
+	"fmt"
+	"strconv"
+)
+
+// counter wraps an int to satisfy flag.Value, every occurrence of the flag
+// on the command line increments it
+type counter struct {
+	value      *int
+	hasBeenSet bool
+}
+
+func newCounter(value int, p *int) *counter {
+	if p == nil {
+		p = new(int)
+	}
+	*p = value
+	return &counter{value: p}
+}
+
+// Set increments the counter for a bare occurrence of the flag ("true"), or
+// sets it to the given explicit count. The first occurrence replaces the
+// value from the environment, a file or the default.
+func (c *counter) Set(value string) error {
+	if n, err := strconv.ParseInt(value, 0, 64); err == nil {
+		*c.value = int(n)
+		c.hasBeenSet = true
+		return nil
+	}
+
+	b, err := strconv.ParseBool(value)
+	if err != nil {
+		return fmt.Errorf("invalid count %q", value)
+	}
+	if !c.hasBeenSet {
+		*c.value = 0
+		c.hasBeenSet = true
+	}
+	if b {
+		*c.value++
+	} else {
+		*c.value = 0
+	}
+	return nil
+}
+
+// String returns a readable representation of this value
+func (c *counter) String() string {
+	if c.value == nil {
+		return "0"
+	}
+	return strconv.Itoa(*c.value)
+}
+
+// Get returns the count
+func (c *counter) Get() interface{} {
+	return *c.value
+}
+
+// IsBoolFlag allows the flag to be given without a value
+func (c *counter) IsBoolFlag() bool {
+	return true
+}
+
+// CountFlag is a flag with type int which counts its occurrences,
+// i.e. -vvv or -v -v -v gives 3
+type CountFlag struct {
+	Name        string
+	Aliases     []string
+	Usage       string
+	EnvVars     []string
+	FilePath    string
+	Required    bool
+	Hidden      bool
+	Value       int
+	DefaultText string
+	Destination *int
+	HasBeenSet  bool
+	Validator   ValidatorFunc
+	Deprecated  string
+	ReplacedBy  string
+	Action      FlagActionFunc
+	NoAutoEnv   bool
+	Persistent  bool
+}
+
+// IsSet returns whether or not the flag has been set through env or file
+func (f *CountFlag) IsSet() bool {
+	return f.HasBeenSet
+}
+
+// String returns a readable representation of this value
+// (for usage defaults)
+func (f *CountFlag) String() string {
+	return FlagStringer(f)
+}
+
+// Names returns the names of the flag
+func (f *CountFlag) Names() []string {
+	return flagNames(f.Name, f.Aliases)
+}
+
+// IsRequired returns whether or not the flag is required
+func (f *CountFlag) IsRequired() bool {
+	return f.Required
+}
+
+// TakesValue returns true of the flag takes a value, otherwise false
+func (f *CountFlag) TakesValue() bool {
+	return false
+}
+
+// GetUsage returns the usage string for the flag
+func (f *CountFlag) GetUsage() string {
+	return f.Usage
+}
+
+// GetValue returns the flags value as string representation and an empty
+// string if the flag takes no value at all.
+func (f *CountFlag) GetValue() string {
+	return fmt.Sprintf("%d", f.Value)
+}
+
+// Apply populates the flag given the flag set and environment
+func (f *CountFlag) Apply(set *flag.FlagSet) error {
+	if val, ok := flagFromEnvOrFile(f.EnvVars, f.FilePath); ok {
+		if val != "" {
+			valInt, err := strconv.ParseInt(val, 0, 64)
+
+			if err != nil {
+				return fmt.Errorf("could not parse %q as count value for flag %s: %s", val, f.Name, err)
+			}
+
+			f.Value = int(valInt)
+			f.HasBeenSet = true
+		}
+	}
+
+	value := newCounter(f.Value, f.Destination)
+	for _, name := range f.Names() {
+		set.Var(value, name, f.Usage)
+	}
+
+	return nil
+}
+
+func (a *App) countVar(p *int, name, alias string, value int, usage, env string) {
+	if a.Flags == nil {
+		a.Flags = make([]Flag, 0)
+	}
+	flag := &CountFlag{
+		Name:        name,
+		Usage:       usage,
+		Value:       value,
+		Destination: p,
+	}
+	if alias != "" {
+		flag.Aliases = []string{alias}
+	}
+	if env != "" {
+		flag.EnvVars = []string{env}
+	}
+	a.Flags = append(a.Flags, flag)
+}
+
+// CountVar defines a count flag with specified name, default value, usage string and env string.
+// The argument p points to a int variable in which to store the value of the flag.
+func (a *App) CountVar(p *int, name string, value int, usage, env string) {
+	a.countVar(p, name, "", value, usage, env)
+}
+
+// CountVarP is like CountVar, but accepts a shorthand letter that can be used after a single dash.
+func (a *App) CountVarP(p *int, name, alias string, value int, usage, env string) {
+	a.countVar(p, name, alias, value, usage, env)
+}
+
+// CountVar defines a count flag with specified name, default value, usage string and env string.
+// The argument p points to a int variable in which to store the value of the flag.
+func CountVar(p *int, name string, value int, usage, env string) {
+	CommandLine.CountVar(p, name, value, usage, env)
+}
+
+// CountVarP is like CountVar, but accepts a shorthand letter that can be used after a single dash.
+func CountVarP(p *int, name, alias string, value int, usage, env string) {
+	CommandLine.CountVarP(p, name, alias, value, usage, env)
+}
+
+// Count defines a count flag with specified name, default value, usage string and env string.
+// The return value is the address of a int variable that stores the value of the flag.
+func (a *App) Count(name string, value int, usage, env string) *int {
+	p := new(int)
+	a.CountVar(p, name, value, usage, env)
+	return p
+}
+
+// CountP is like Count, but accepts a shorthand letter that can be used after a single dash.
+func (a *App) CountP(name, alias string, value int, usage, env string) *int {
+	p := new(int)
+	a.CountVarP(p, name, alias, value, usage, env)
+	return p
+}
+
+// Count looks up the value of a local CountFlag, returns
+// 0 if not found
+func (c *Context) Count(name string) int {
+	if fs := c.strictFlagSet(name); fs != nil {
+		return lookupCount(name, fs)
+	}
+	return 0
+}
+
+// GetCount looks up the value of a CountFlag, returns an error if the
+// flag is not defined
+func (c *Context) GetCount(name string) (int, error) {
+	fs, err := c.definedFlagSet(name)
+	if err != nil {
+		return 0, err
+	}
+	return lookupCount(name, fs), nil
+}
+
+func lookupCount(name string, set *flag.FlagSet) int {
+	f := set.Lookup(name)
+	if f != nil {
+		parsed, err := strconv.ParseInt(f.Value.String(), 0, 64)
+		if err != nil {
+			return 0
+		}
+		return int(parsed)
+	}
+	return 0
+}
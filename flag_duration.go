@@ -22,17 +22,24 @@ import (
 
 // DurationFlag is a flag with type time.Duration (see https://golang.org/pkg/time/#ParseDuration)
 type DurationFlag struct {
-	Name        string
-	Aliases     []string
-	Usage       string
-	EnvVars     []string
-	FilePath    string
-	Required    bool
-	Hidden      bool
-	Value       time.Duration
-	DefaultText string
-	Destination *time.Duration
-	HasBeenSet  bool
+	Name          string
+	Aliases       []string
+	Usage         string
+	EnvVars       []string
+	FilePath      string
+	Required      bool
+	Hidden        bool
+	Value         time.Duration
+	DefaultText   string
+	Destination   *time.Duration
+	HasBeenSet    bool
+	Validator     ValidatorFunc
+	Deprecated    string
+	ReplacedBy    string
+	Action        FlagActionFunc
+	NoAutoEnv     bool
+	ValueFromFile bool
+	Persistent    bool
 }
 
 // IsSet returns whether or not the flag has been set through env or file
@@ -157,12 +164,22 @@ func (a *App) DurationP(name, alias string, value time.Duration, usage, env stri
 // Duration looks up the value of a local DurationFlag, returns
 // 0 if not found
 func (c *Context) Duration(name string) time.Duration {
-	if fs := lookupFlagSet(name, c); fs != nil {
+	if fs := c.strictFlagSet(name); fs != nil {
 		return lookupDuration(name, fs)
 	}
 	return 0
 }
 
+// GetDuration looks up the value of a DurationFlag, returns an error if the
+// flag is not defined
+func (c *Context) GetDuration(name string) (time.Duration, error) {
+	fs, err := c.definedFlagSet(name)
+	if err != nil {
+		return 0, err
+	}
+	return lookupDuration(name, fs), nil
+}
+
 func lookupDuration(name string, set *flag.FlagSet) time.Duration {
 	f := set.Lookup(name)
 	if f != nil {
@@ -0,0 +1,186 @@
+// Copyright 2020 The vine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cli
+
+import (
+	"flag"
+	"fmt"
+	"strings"
+)
+
+// enumValue wraps a string restricted to a set of allowed values to
+// satisfy flag.Value
+type enumValue struct {
+	allowed []string
+	value   *string
+}
+
+func newEnumValue(allowed []string, value string, p *string) *enumValue {
+	if p == nil {
+		p = new(string)
+	}
+	*p = value
+	return &enumValue{allowed: allowed, value: p}
+}
+
+// Set sets the value if it is one of the allowed values
+func (e *enumValue) Set(value string) error {
+	if err := checkAllowed(e.allowed, value); err != nil {
+		return err
+	}
+	*e.value = value
+	return nil
+}
+
+// String returns a readable representation of this value
+func (e *enumValue) String() string {
+	if e.value == nil {
+		return ""
+	}
+	return *e.value
+}
+
+// Get returns the value
+func (e *enumValue) Get() interface{} {
+	return *e.value
+}
+
+func checkAllowed(allowed []string, value string) error {
+	for _, a := range allowed {
+		if a == value {
+			return nil
+		}
+	}
+	return fmt.Errorf("must be one of %s", strings.Join(allowed, ", "))
+}
+
+// EnumFlag is a flag with type string restricted to the Allowed values
+type EnumFlag struct {
+	Name          string
+	Aliases       []string
+	Usage         string
+	EnvVars       []string
+	FilePath      string
+	Required      bool
+	Hidden        bool
+	Allowed       []string
+	Value         string
+	DefaultText   string
+	Destination   *string
+	HasBeenSet    bool
+	Validator     ValidatorFunc
+	Deprecated    string
+	ReplacedBy    string
+	Action        FlagActionFunc
+	NoAutoEnv     bool
+	ValueFromFile bool
+	Persistent    bool
+}
+
+// IsSet returns whether or not the flag has been set through env or file
+func (f *EnumFlag) IsSet() bool {
+	return f.HasBeenSet
+}
+
+// String returns a readable representation of this value
+// (for usage defaults)
+func (f *EnumFlag) String() string {
+	return FlagStringer(f)
+}
+
+// Names returns the names of the flag
+func (f *EnumFlag) Names() []string {
+	return flagNames(f.Name, f.Aliases)
+}
+
+// IsRequired returns whether or not the flag is required
+func (f *EnumFlag) IsRequired() bool {
+	return f.Required
+}
+
+// TakesValue returns true of the flag takes a value, otherwise false
+func (f *EnumFlag) TakesValue() bool {
+	return true
+}
+
+// GetUsage returns the usage string for the flag
+func (f *EnumFlag) GetUsage() string {
+	return f.Usage
+}
+
+// GetValue returns the flags value as string representation and an empty
+// string if the flag takes no value at all.
+func (f *EnumFlag) GetValue() string {
+	return f.Value
+}
+
+// GetAllowed returns the values accepted by the flag
+func (f *EnumFlag) GetAllowed() []string {
+	return f.Allowed
+}
+
+// Apply populates the flag given the flag set and environment
+func (f *EnumFlag) Apply(set *flag.FlagSet) error {
+	if val, ok := flagFromEnvOrFile(f.EnvVars, f.FilePath); ok {
+		if val != "" {
+			if err := checkAllowed(f.Allowed, val); err != nil {
+				return fmt.Errorf("could not parse %q as enum value for flag %s: %s", val, f.Name, err)
+			}
+
+			f.Value = val
+			f.HasBeenSet = true
+		}
+	}
+
+	// an empty default leaves the flag unset
+	if f.Value != "" {
+		if err := checkAllowed(f.Allowed, f.Value); err != nil {
+			return fmt.Errorf("invalid default value %q for flag %s: %s", f.Value, f.Name, err)
+		}
+	}
+
+	for _, name := range f.Names() {
+		set.Var(newEnumValue(f.Allowed, f.Value, f.Destination), name, f.Usage)
+	}
+
+	return nil
+}
+
+// Enum looks up the value of a local EnumFlag, returns
+// "" if not found
+func (c *Context) Enum(name string) string {
+	if fs := c.strictFlagSet(name); fs != nil {
+		return lookupEnum(name, fs)
+	}
+	return ""
+}
+
+// GetEnum looks up the value of an EnumFlag, returns an error if the
+// flag is not defined
+func (c *Context) GetEnum(name string) (string, error) {
+	fs, err := c.definedFlagSet(name)
+	if err != nil {
+		return "", err
+	}
+	return lookupEnum(name, fs), nil
+}
+
+func lookupEnum(name string, set *flag.FlagSet) string {
+	f := set.Lookup(name)
+	if f != nil {
+		return f.Value.String()
+	}
+	return ""
+}
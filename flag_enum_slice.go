@@ -0,0 +1,147 @@
+// Copyright 2020 The vine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cli
+
+import (
+	"flag"
+	"fmt"
+	"strings"
+)
+
+// EnumSliceFlag is a flag with type *StringSlice whose items are
+// restricted to the Allowed values
+type EnumSliceFlag struct {
+	Name          string
+	Aliases       []string
+	Usage         string
+	EnvVars       []string
+	FilePath      string
+	Required      bool
+	Hidden        bool
+	Allowed       []string
+	Value         *StringSlice
+	DefaultText   string
+	HasBeenSet    bool
+	Validator     ValidatorFunc
+	Deprecated    string
+	ReplacedBy    string
+	Action        FlagActionFunc
+	NoAutoEnv     bool
+	ValueFromFile bool
+	Persistent    bool
+}
+
+// IsSet returns whether or not the flag has been set through env or file
+func (f *EnumSliceFlag) IsSet() bool {
+	return f.HasBeenSet
+}
+
+// String returns a readable representation of this value
+// (for usage defaults)
+func (f *EnumSliceFlag) String() string {
+	return FlagStringer(f)
+}
+
+// Names returns the names of the flag
+func (f *EnumSliceFlag) Names() []string {
+	return flagNames(f.Name, f.Aliases)
+}
+
+// IsRequired returns whether or not the flag is required
+func (f *EnumSliceFlag) IsRequired() bool {
+	return f.Required
+}
+
+// TakesValue returns true of the flag takes a value, otherwise false
+func (f *EnumSliceFlag) TakesValue() bool {
+	return true
+}
+
+// GetUsage returns the usage string for the flag
+func (f *EnumSliceFlag) GetUsage() string {
+	return f.Usage
+}
+
+// GetValue returns the flags value as string representation and an empty
+// string if the flag takes no value at all.
+func (f *EnumSliceFlag) GetValue() string {
+	if f.Value != nil {
+		return f.Value.String()
+	}
+	return ""
+}
+
+// GetAllowed returns the values accepted by the flag
+func (f *EnumSliceFlag) GetAllowed() []string {
+	return f.Allowed
+}
+
+func (f *EnumSliceFlag) validate(item string) error {
+	return checkAllowed(f.Allowed, item)
+}
+
+// Apply populates the flag given the flag set and environment
+func (f *EnumSliceFlag) Apply(set *flag.FlagSet) error {
+	if val, ok := flagFromEnvOrFile(f.EnvVars, f.FilePath); ok && val != "" {
+		value := &validatedSlice{validate: f.validate, slice: &StringSlice{}}
+
+		for _, s := range strings.Split(val, ",") {
+			if err := value.Set(strings.TrimSpace(s)); err != nil {
+				return fmt.Errorf("could not parse %q as enum value for flag %s: %s", val, f.Name, err)
+			}
+		}
+
+		f.Value = value.slice
+		f.HasBeenSet = true
+	}
+
+	if f.Value == nil {
+		f.Value = &StringSlice{}
+	}
+	for _, item := range f.Value.Value() {
+		if err := f.validate(item); err != nil {
+			return fmt.Errorf("invalid default value %q for flag %s: %s", item, f.Name, err)
+		}
+	}
+	value := &validatedSlice{validate: f.validate, slice: f.Value}
+	for _, name := range f.Names() {
+		set.Var(value, name, f.Usage)
+	}
+
+	return nil
+}
+
+// EnumSlice looks up the value of a local EnumSliceFlag, returns
+// nil if not found
+func (c *Context) EnumSlice(name string) []string {
+	if fs := c.strictFlagSet(name); fs != nil {
+		return lookupEnumSlice(name, fs)
+	}
+	return nil
+}
+
+// GetEnumSlice looks up the value of an EnumSliceFlag, returns an error if the
+// flag is not defined
+func (c *Context) GetEnumSlice(name string) ([]string, error) {
+	fs, err := c.definedFlagSet(name)
+	if err != nil {
+		return nil, err
+	}
+	return lookupEnumSlice(name, fs), nil
+}
+
+func lookupEnumSlice(name string, set *flag.FlagSet) []string {
+	return lookupValidatedSlice(name, set)
+}
@@ -22,17 +22,24 @@ import (
 
 // Float64Flag is a flag with type float64
 type Float64Flag struct {
-	Name        string
-	Aliases     []string
-	Usage       string
-	EnvVars     []string
-	FilePath    string
-	Required    bool
-	Hidden      bool
-	Value       float64
-	DefaultText string
-	Destination *float64
-	HasBeenSet  bool
+	Name          string
+	Aliases       []string
+	Usage         string
+	EnvVars       []string
+	FilePath      string
+	Required      bool
+	Hidden        bool
+	Value         float64
+	DefaultText   string
+	Destination   *float64
+	HasBeenSet    bool
+	Validator     ValidatorFunc
+	Deprecated    string
+	ReplacedBy    string
+	Action        FlagActionFunc
+	NoAutoEnv     bool
+	ValueFromFile bool
+	Persistent    bool
 }
 
 // IsSet returns whether or not the flag has been set through env or file
@@ -157,12 +164,22 @@ func (a *App) Float64P(name, alias string, value float64, usage, env string) *fl
 // Float64 looks up the value of a local Float64Flag, returns
 // 0 if not found
 func (c *Context) Float64(name string) float64 {
-	if fs := lookupFlagSet(name, c); fs != nil {
+	if fs := c.strictFlagSet(name); fs != nil {
 		return lookupFloat64(name, fs)
 	}
 	return 0
 }
 
+// GetFloat64 looks up the value of a Float64Flag, returns an error if the
+// flag is not defined
+func (c *Context) GetFloat64(name string) (float64, error) {
+	fs, err := c.definedFlagSet(name)
+	if err != nil {
+		return 0, err
+	}
+	return lookupFloat64(name, fs), nil
+}
+
 func lookupFloat64(name string, set *flag.FlagSet) float64 {
 	f := set.Lookup(name)
 	if f != nil {
@@ -46,7 +46,11 @@ func newFloat64Slice(val []float64, p *[]float64) *Float64Slice {
 // Set parses the value into a float64 and appends it to the list of values
 func (f *Float64Slice) Set(value string) error {
 	if !f.hasBeenSet {
-		f.val = &[]float64{}
+		if f.val == nil {
+			f.val = &[]float64{}
+		} else {
+			*f.val = []float64{}
+		}
 		f.hasBeenSet = true
 	}
 
@@ -118,16 +122,23 @@ func (f *Float64Slice) Get() interface{} {
 
 // Float64SliceFlag is a flag with type *Float64Slice
 type Float64SliceFlag struct {
-	Name        string
-	Aliases     []string
-	Usage       string
-	EnvVars     []string
-	FilePath    string
-	Required    bool
-	Hidden      bool
-	Value       *Float64Slice
-	DefaultText string
-	HasBeenSet  bool
+	Name          string
+	Aliases       []string
+	Usage         string
+	EnvVars       []string
+	FilePath      string
+	Required      bool
+	Hidden        bool
+	Value         *Float64Slice
+	DefaultText   string
+	HasBeenSet    bool
+	Validator     ValidatorFunc
+	Deprecated    string
+	ReplacedBy    string
+	Action        FlagActionFunc
+	NoAutoEnv     bool
+	ValueFromFile bool
+	Persistent    bool
 }
 
 // IsSet returns whether or not the flag has been set through env or file
@@ -174,7 +185,12 @@ func (f *Float64SliceFlag) GetValue() string {
 func (f *Float64SliceFlag) Apply(set *flag.FlagSet) error {
 	if val, ok := flagFromEnvOrFile(f.EnvVars, f.FilePath); ok {
 		if val != "" {
-			f.Value = &Float64Slice{}
+			// parse into the existing value to keep its destination, the
+			// value replacing the default
+			if f.Value == nil {
+				f.Value = &Float64Slice{}
+			}
+			f.Value.hasBeenSet = false
 
 			for _, s := range strings.Split(val, ",") {
 				if err := f.Value.Set(strings.TrimSpace(s)); err != nil {
@@ -254,12 +270,22 @@ func (a *App) Float64SliceP(name, alias string, value []float64, usage, env stri
 // Float64Slice looks up the value of a local Float64SliceFlag, returns
 // nil if not found
 func (c *Context) Float64Slice(name string) []float64 {
-	if fs := lookupFlagSet(name, c); fs != nil {
+	if fs := c.strictFlagSet(name); fs != nil {
 		return lookupFloat64Slice(name, fs)
 	}
 	return nil
 }
 
+// GetFloat64Slice looks up the value of a Float64SliceFlag, returns an error if the
+// flag is not defined
+func (c *Context) GetFloat64Slice(name string) ([]float64, error) {
+	fs, err := c.definedFlagSet(name)
+	if err != nil {
+		return nil, err
+	}
+	return lookupFloat64Slice(name, fs), nil
+}
+
 func lookupFloat64Slice(name string, set *flag.FlagSet) []float64 {
 	f := set.Lookup(name)
 	if f != nil {
@@ -27,17 +27,24 @@ type Generic interface {
 
 // GenericFlag is a flag with type Generic
 type GenericFlag struct {
-	Name        string
-	Aliases     []string
-	Usage       string
-	EnvVars     []string
-	FilePath    string
-	Required    bool
-	Hidden      bool
-	TakesFile   bool
-	Value       Generic
-	DefaultText string
-	HasBeenSet  bool
+	Name          string
+	Aliases       []string
+	Usage         string
+	EnvVars       []string
+	FilePath      string
+	Required      bool
+	Hidden        bool
+	TakesFile     bool
+	Value         Generic
+	DefaultText   string
+	HasBeenSet    bool
+	Validator     ValidatorFunc
+	Deprecated    string
+	ReplacedBy    string
+	Action        FlagActionFunc
+	NoAutoEnv     bool
+	ValueFromFile bool
+	Persistent    bool
 }
 
 // IsSet returns whether or not the flag has been set through env or file
@@ -103,12 +110,22 @@ func (f GenericFlag) Apply(set *flag.FlagSet) error {
 // Generic looks up the value of a local GenericFlag, returns
 // nil if not found
 func (c *Context) Generic(name string) interface{} {
-	if fs := lookupFlagSet(name, c); fs != nil {
+	if fs := c.strictFlagSet(name); fs != nil {
 		return lookupGeneric(name, fs)
 	}
 	return nil
 }
 
+// GetGeneric looks up the value of a GenericFlag, returns an error if the
+// flag is not defined
+func (c *Context) GetGeneric(name string) (interface{}, error) {
+	fs, err := c.definedFlagSet(name)
+	if err != nil {
+		return nil, err
+	}
+	return lookupGeneric(name, fs), nil
+}
+
 func lookupGeneric(name string, set *flag.FlagSet) interface{} {
 	f := set.Lookup(name)
 	if f != nil {
@@ -119,4 +136,4 @@ func lookupGeneric(name string, set *flag.FlagSet) interface{} {
 		return parsed
 	}
 	return nil
-}
\ No newline at end of file
+}
@@ -0,0 +1,198 @@
+// Copyright 2020 The vine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cli
+
+import (
+	"fmt"
+	"strings"
+)
+
+// FlagGroupKind is the relationship enforced between the flags of a FlagGroup
+type FlagGroupKind int
+
+const (
+	// MutuallyExclusiveFlags allows at most one of the flags to be set
+	MutuallyExclusiveFlags FlagGroupKind = iota
+	// RequiredTogetherFlags requires either all or none of the flags to be set
+	RequiredTogetherFlags
+	// ExactlyOneFlag requires exactly one of the flags to be set
+	ExactlyOneFlag
+	// AtLeastOneFlag requires one or more of the flags to be set
+	AtLeastOneFlag
+	// RequiresFlags requires the other flags to be set once the first one
+	// is set, but not the other way around
+	RequiresFlags
+)
+
+// FlagGroup is a constraint on a set of flags, checked after the flags are
+// parsed in the same way as required flags
+type FlagGroup struct {
+	Kind  FlagGroupKind
+	Flags []string
+}
+
+// MutuallyExclusive returns a group of flags of which at most one may be set
+func MutuallyExclusive(names ...string) FlagGroup {
+	return FlagGroup{Kind: MutuallyExclusiveFlags, Flags: names}
+}
+
+// RequiredTogether returns a group of flags which must be set together
+func RequiredTogether(names ...string) FlagGroup {
+	return FlagGroup{Kind: RequiredTogetherFlags, Flags: names}
+}
+
+// ExactlyOne returns a group of flags of which exactly one must be set
+func ExactlyOne(names ...string) FlagGroup {
+	return FlagGroup{Kind: ExactlyOneFlag, Flags: names}
+}
+
+// AtLeastOne returns a group of flags of which at least one must be set
+func AtLeastOne(names ...string) FlagGroup {
+	return FlagGroup{Kind: AtLeastOneFlag, Flags: names}
+}
+
+// Requires returns a group in which the flag named name requires the
+// dependencies to be set, which may be set on their own
+func Requires(name string, dependencies ...string) FlagGroup {
+	return FlagGroup{Kind: RequiresFlags, Flags: append([]string{name}, dependencies...)}
+}
+
+// Synopsis returns the flags of the group, i.e. "(--token | --token-file)",
+// or the first flag for a Requires group, i.e. "--cert"
+func (g FlagGroup) Synopsis() string {
+	if g.Kind == RequiresFlags && len(g.Flags) > 0 {
+		return g.prefixed()[0]
+	}
+	return "(" + strings.Join(g.prefixed(), " | ") + ")"
+}
+
+// prefixed returns the flags of the group with their dashes
+func (g FlagGroup) prefixed() []string {
+	flags := g.Flags
+	names := make([]string, 0, len(flags))
+	for _, name := range flags {
+		names = append(names, prefixFor(name)+name)
+	}
+	return names
+}
+
+// Description returns the relationship between the flags of the group
+func (g FlagGroup) Description() string {
+	switch g.Kind {
+	case MutuallyExclusiveFlags:
+		return "mutually exclusive"
+	case RequiredTogetherFlags:
+		return "required together"
+	case ExactlyOneFlag:
+		return "exactly one required"
+	case AtLeastOneFlag:
+		return "at least one required"
+	case RequiresFlags:
+		if len(g.Flags) > 1 {
+			return "requires " + strings.Join(g.prefixed()[1:], ", ")
+		}
+	}
+	return ""
+}
+
+// String returns a readable representation of the group (for help output)
+func (g FlagGroup) String() string {
+	return fmt.Sprintf("%s\t%s", g.Synopsis(), g.Description())
+}
+
+// check returns an error if the flags set in the context break the group
+func (g FlagGroup) check(flags []Flag, context *Context) error {
+	var set, missing []string
+	for _, name := range g.Flags {
+		if flagGroupMemberSet(name, flags, context) {
+			set = append(set, name)
+		} else {
+			missing = append(missing, name)
+		}
+	}
+
+	var broken bool
+	switch g.Kind {
+	case MutuallyExclusiveFlags:
+		broken = len(set) > 1
+	case RequiredTogetherFlags:
+		broken = len(set) > 0 && len(missing) > 0
+	case ExactlyOneFlag:
+		broken = len(set) != 1
+	case AtLeastOneFlag:
+		broken = len(set) == 0
+	case RequiresFlags:
+		broken = len(g.Flags) > 0 && len(set) > 0 && set[0] == g.Flags[0] && len(missing) > 0
+	}
+
+	if broken {
+		return &errFlagGroup{group: g, set: set, missing: missing}
+	}
+	return nil
+}
+
+// flagGroupMemberSet reports whether the flag named name, under any of its
+// names, has been set
+func flagGroupMemberSet(name string, flags []Flag, context *Context) bool {
+	names := []string{name}
+	for _, f := range flags {
+		for _, n := range f.Names() {
+			if n == name {
+				names = f.Names()
+			}
+		}
+	}
+
+	for _, n := range names {
+		if context.IsSet(strings.TrimSpace(n)) {
+			return true
+		}
+	}
+	return false
+}
+
+type errFlagGroup struct {
+	group   FlagGroup
+	set     []string
+	missing []string
+}
+
+func (e *errFlagGroup) Error() string {
+	all := strings.Join(e.group.Flags, ", ")
+	switch e.group.Kind {
+	case MutuallyExclusiveFlags:
+		return fmt.Sprintf("Flags %q are mutually exclusive", strings.Join(e.set, ", "))
+	case RequiredTogetherFlags:
+		return fmt.Sprintf("Flags %q must be set together, %q not set", all, strings.Join(e.missing, ", "))
+	case ExactlyOneFlag:
+		if len(e.set) > 1 {
+			return fmt.Sprintf("Only one of flags %q can be set", all)
+		}
+		return fmt.Sprintf("One of flags %q must be set", all)
+	case RequiresFlags:
+		return fmt.Sprintf("Flag %q requires %q, %q not set", e.group.Flags[0],
+			strings.Join(e.group.Flags[1:], ", "), strings.Join(e.missing, ", "))
+	}
+	return fmt.Sprintf("At least one of flags %q must be set", all)
+}
+
+func checkFlagGroups(groups []FlagGroup, flags []Flag, context *Context) error {
+	for _, g := range groups {
+		if err := g.check(flags, context); err != nil {
+			return err
+		}
+	}
+	return nil
+}
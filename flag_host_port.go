@@ -0,0 +1,292 @@
+// Copyright 2020 The vine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cli
+
+import (
+	"flag"
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+)
+
+// hostPortValue wraps a "host:port" string to satisfy flag.Value
+type hostPortValue struct {
+	value *string
+}
+
+func newHostPortValue(value string, p *string) *hostPortValue {
+	if p == nil {
+		p = new(string)
+	}
+	*p = value
+	return &hostPortValue{value: p}
+}
+
+// Set sets the value if it is a valid "host:port" address
+func (h *hostPortValue) Set(value string) error {
+	value = strings.TrimSpace(value)
+	if err := validateHostPort(value); err != nil {
+		return err
+	}
+	*h.value = value
+	return nil
+}
+
+// String returns a readable representation of this value
+func (h *hostPortValue) String() string {
+	if h.value == nil {
+		return ""
+	}
+	return *h.value
+}
+
+// Get returns the address
+func (h *hostPortValue) Get() interface{} {
+	return *h.value
+}
+
+// validateHostPort checks the value is a "host:port" address with a numeric
+// port, the host may be empty to denote all interfaces, i.e. ":8080"
+func validateHostPort(value string) error {
+	_, port, err := net.SplitHostPort(strings.TrimSpace(value))
+	if err != nil {
+		return fmt.Errorf("invalid host:port address %q", value)
+	}
+	if _, err := strconv.ParseUint(port, 10, 16); err != nil {
+		return fmt.Errorf("invalid port %q in address %q", port, value)
+	}
+	return nil
+}
+
+// HostPortFlag is a flag with type string holding a "host:port" address
+type HostPortFlag struct {
+	Name          string
+	Aliases       []string
+	Usage         string
+	EnvVars       []string
+	FilePath      string
+	Required      bool
+	Hidden        bool
+	Value         string
+	DefaultText   string
+	Destination   *string
+	HasBeenSet    bool
+	Validator     ValidatorFunc
+	Deprecated    string
+	ReplacedBy    string
+	Action        FlagActionFunc
+	NoAutoEnv     bool
+	ValueFromFile bool
+	Persistent    bool
+}
+
+// IsSet returns whether or not the flag has been set through env or file
+func (f *HostPortFlag) IsSet() bool {
+	return f.HasBeenSet
+}
+
+// String returns a readable representation of this value
+// (for usage defaults)
+func (f *HostPortFlag) String() string {
+	return FlagStringer(f)
+}
+
+// Names returns the names of the flag
+func (f *HostPortFlag) Names() []string {
+	return flagNames(f.Name, f.Aliases)
+}
+
+// IsRequired returns whether or not the flag is required
+func (f *HostPortFlag) IsRequired() bool {
+	return f.Required
+}
+
+// TakesValue returns true of the flag takes a value, otherwise false
+func (f *HostPortFlag) TakesValue() bool {
+	return true
+}
+
+// GetUsage returns the usage string for the flag
+func (f *HostPortFlag) GetUsage() string {
+	return f.Usage
+}
+
+// GetValue returns the flags value as string representation and an empty
+// string if the flag takes no value at all.
+func (f *HostPortFlag) GetValue() string {
+	return f.Value
+}
+
+// Apply populates the flag given the flag set and environment
+func (f *HostPortFlag) Apply(set *flag.FlagSet) error {
+	if val, ok := flagFromEnvOrFile(f.EnvVars, f.FilePath); ok {
+		if val != "" {
+			if err := validateHostPort(val); err != nil {
+				return fmt.Errorf("could not parse %q as host:port value for flag %s: %s", val, f.Name, err)
+			}
+
+			f.Value = strings.TrimSpace(val)
+			f.HasBeenSet = true
+		}
+	}
+
+	value := newHostPortValue(f.Value, f.Destination)
+	for _, name := range f.Names() {
+		set.Var(value, name, f.Usage)
+	}
+
+	return nil
+}
+
+// HostPort looks up the value of a local HostPortFlag, returns
+// "" if not found
+func (c *Context) HostPort(name string) string {
+	if fs := c.strictFlagSet(name); fs != nil {
+		return lookupHostPort(name, fs)
+	}
+	return ""
+}
+
+// GetHostPort looks up the value of a HostPortFlag, returns an error if the
+// flag is not defined
+func (c *Context) GetHostPort(name string) (string, error) {
+	fs, err := c.definedFlagSet(name)
+	if err != nil {
+		return "", err
+	}
+	return lookupHostPort(name, fs), nil
+}
+
+func lookupHostPort(name string, set *flag.FlagSet) string {
+	f := set.Lookup(name)
+	if f != nil {
+		if value, ok := f.Value.(*hostPortValue); ok {
+			return *value.value
+		}
+	}
+	return ""
+}
+
+// HostPortSliceFlag is a flag with type *StringSlice whose items are
+// "host:port" addresses
+type HostPortSliceFlag struct {
+	Name          string
+	Aliases       []string
+	Usage         string
+	EnvVars       []string
+	FilePath      string
+	Required      bool
+	Hidden        bool
+	Value         *StringSlice
+	DefaultText   string
+	HasBeenSet    bool
+	Validator     ValidatorFunc
+	Deprecated    string
+	ReplacedBy    string
+	Action        FlagActionFunc
+	NoAutoEnv     bool
+	ValueFromFile bool
+	Persistent    bool
+}
+
+// IsSet returns whether or not the flag has been set through env or file
+func (f *HostPortSliceFlag) IsSet() bool {
+	return f.HasBeenSet
+}
+
+// String returns a readable representation of this value
+// (for usage defaults)
+func (f *HostPortSliceFlag) String() string {
+	return FlagStringer(f)
+}
+
+// Names returns the names of the flag
+func (f *HostPortSliceFlag) Names() []string {
+	return flagNames(f.Name, f.Aliases)
+}
+
+// IsRequired returns whether or not the flag is required
+func (f *HostPortSliceFlag) IsRequired() bool {
+	return f.Required
+}
+
+// TakesValue returns true of the flag takes a value, otherwise false
+func (f *HostPortSliceFlag) TakesValue() bool {
+	return true
+}
+
+// GetUsage returns the usage string for the flag
+func (f *HostPortSliceFlag) GetUsage() string {
+	return f.Usage
+}
+
+// GetValue returns the flags value as string representation and an empty
+// string if the flag takes no value at all.
+func (f *HostPortSliceFlag) GetValue() string {
+	if f.Value != nil {
+		return f.Value.String()
+	}
+	return ""
+}
+
+// Apply populates the flag given the flag set and environment
+func (f *HostPortSliceFlag) Apply(set *flag.FlagSet) error {
+	if val, ok := flagFromEnvOrFile(f.EnvVars, f.FilePath); ok {
+		value := &validatedSlice{validate: validateHostPort, slice: &StringSlice{}}
+
+		for _, s := range strings.Split(val, ",") {
+			if err := value.Set(strings.TrimSpace(s)); err != nil {
+				return fmt.Errorf("could not parse %q as host:port value for flag %s: %s", val, f.Name, err)
+			}
+		}
+
+		f.Value = value.slice
+		f.HasBeenSet = true
+	}
+
+	if f.Value == nil {
+		f.Value = &StringSlice{}
+	}
+	value := &validatedSlice{validate: validateHostPort, slice: f.Value}
+	for _, name := range f.Names() {
+		set.Var(value, name, f.Usage)
+	}
+
+	return nil
+}
+
+// HostPortSlice looks up the value of a local HostPortSliceFlag, returns
+// nil if not found
+func (c *Context) HostPortSlice(name string) []string {
+	if fs := c.strictFlagSet(name); fs != nil {
+		return lookupHostPortSlice(name, fs)
+	}
+	return nil
+}
+
+// GetHostPortSlice looks up the value of a HostPortSliceFlag, returns an error if the
+// flag is not defined
+func (c *Context) GetHostPortSlice(name string) ([]string, error) {
+	fs, err := c.definedFlagSet(name)
+	if err != nil {
+		return nil, err
+	}
+	return lookupHostPortSlice(name, fs), nil
+}
+
+func lookupHostPortSlice(name string, set *flag.FlagSet) []string {
+	return lookupValidatedSlice(name, set)
+}
@@ -22,17 +22,24 @@ import (
 
 // IntFlag is a flag with type int
 type IntFlag struct {
-	Name        string
-	Aliases     []string
-	Usage       string
-	EnvVars     []string
-	FilePath    string
-	Required    bool
-	Hidden      bool
-	Value       int
-	DefaultText string
-	Destination *int
-	HasBeenSet  bool
+	Name          string
+	Aliases       []string
+	Usage         string
+	EnvVars       []string
+	FilePath      string
+	Required      bool
+	Hidden        bool
+	Value         int
+	DefaultText   string
+	Destination   *int
+	HasBeenSet    bool
+	Validator     ValidatorFunc
+	Deprecated    string
+	ReplacedBy    string
+	Action        FlagActionFunc
+	NoAutoEnv     bool
+	ValueFromFile bool
+	Persistent    bool
 }
 
 // IsSet returns whether or not the flag has been set through env or file
@@ -157,12 +164,22 @@ func (a *App) IntP(name, alias string, value int, usage, env string) *int {
 // Int looks up the value of a local IntFlag, returns
 // 0 if not found
 func (c *Context) Int(name string) int {
-	if fs := lookupFlagSet(name, c); fs != nil {
+	if fs := c.strictFlagSet(name); fs != nil {
 		return lookupInt(name, fs)
 	}
 	return 0
 }
 
+// GetInt looks up the value of an IntFlag, returns an error if the
+// flag is not defined
+func (c *Context) GetInt(name string) (int, error) {
+	fs, err := c.definedFlagSet(name)
+	if err != nil {
+		return 0, err
+	}
+	return lookupInt(name, fs), nil
+}
+
 func lookupInt(name string, set *flag.FlagSet) int {
 	f := set.Lookup(name)
 	if f != nil {
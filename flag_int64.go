@@ -22,17 +22,24 @@ import (
 
 // Int64Flag is a flag with type int64
 type Int64Flag struct {
-	Name        string
-	Aliases     []string
-	Usage       string
-	EnvVars     []string
-	FilePath    string
-	Required    bool
-	Hidden      bool
-	Value       int64
-	DefaultText string
-	Destination *int64
-	HasBeenSet  bool
+	Name          string
+	Aliases       []string
+	Usage         string
+	EnvVars       []string
+	FilePath      string
+	Required      bool
+	Hidden        bool
+	Value         int64
+	DefaultText   string
+	Destination   *int64
+	HasBeenSet    bool
+	Validator     ValidatorFunc
+	Deprecated    string
+	ReplacedBy    string
+	Action        FlagActionFunc
+	NoAutoEnv     bool
+	ValueFromFile bool
+	Persistent    bool
 }
 
 // IsSet returns whether or not the flag has been set through env or file
@@ -156,12 +163,22 @@ func (a *App) Int64P(name, alias string, value int64, usage, env string) *int64
 // Int64 looks up the value of a local Int64Flag, returns
 // 0 if not found
 func (c *Context) Int64(name string) int64 {
-	if fs := lookupFlagSet(name, c); fs != nil {
+	if fs := c.strictFlagSet(name); fs != nil {
 		return lookupInt64(name, fs)
 	}
 	return 0
 }
 
+// GetInt64 looks up the value of an Int64Flag, returns an error if the
+// flag is not defined
+func (c *Context) GetInt64(name string) (int64, error) {
+	fs, err := c.definedFlagSet(name)
+	if err != nil {
+		return 0, err
+	}
+	return lookupInt64(name, fs), nil
+}
+
 func lookupInt64(name string, set *flag.FlagSet) int64 {
 	f := set.Lookup(name)
 	if f != nil {
@@ -46,7 +46,11 @@ func newInt64Slice(value []int64, p *[]int64) *Int64Slice {
 // Set parses the value into an integer and appends it to the list of values
 func (i *Int64Slice) Set(value string) error {
 	if !i.hasBeenSet {
-		i.value = &[]int64{}
+		if i.value == nil {
+			i.value = &[]int64{}
+		} else {
+			*i.value = []int64{}
+		}
 		i.hasBeenSet = true
 	}
 
@@ -118,16 +122,23 @@ func (i *Int64Slice) Get() interface{} {
 
 // Int64SliceFlag is a flag with type *Int64Slice
 type Int64SliceFlag struct {
-	Name        string
-	Aliases     []string
-	Usage       string
-	EnvVars     []string
-	FilePath    string
-	Required    bool
-	Hidden      bool
-	Value       *Int64Slice
-	DefaultText string
-	HasBeenSet  bool
+	Name          string
+	Aliases       []string
+	Usage         string
+	EnvVars       []string
+	FilePath      string
+	Required      bool
+	Hidden        bool
+	Value         *Int64Slice
+	DefaultText   string
+	HasBeenSet    bool
+	Validator     ValidatorFunc
+	Deprecated    string
+	ReplacedBy    string
+	Action        FlagActionFunc
+	NoAutoEnv     bool
+	ValueFromFile bool
+	Persistent    bool
 }
 
 // IsSet returns whether or not the flag has been set through env or file
@@ -173,7 +184,12 @@ func (f *Int64SliceFlag) GetValue() string {
 // Apply populates the flag given the flag set and environment
 func (f *Int64SliceFlag) Apply(set *flag.FlagSet) error {
 	if val, ok := flagFromEnvOrFile(f.EnvVars, f.FilePath); ok {
-		f.Value = &Int64Slice{}
+		// parse into the existing value to keep its destination, the
+		// value replacing the default
+		if f.Value == nil {
+			f.Value = &Int64Slice{}
+		}
+		f.Value.hasBeenSet = false
 
 		for _, s := range strings.Split(val, ",") {
 			if err := f.Value.Set(strings.TrimSpace(s)); err != nil {
@@ -252,7 +268,20 @@ func (a *App) Int64SliceP(name, alias string, value []int64, usage, env string)
 // Int64Slice looks up the value of a local Int64SliceFlag, returns
 // nil if not found
 func (c *Context) Int64Slice(name string) []int64 {
-	return lookupInt64Slice(name, c.flagSet)
+	if fs := c.strictFlagSet(name); fs != nil {
+		return lookupInt64Slice(name, fs)
+	}
+	return nil
+}
+
+// GetInt64Slice looks up the value of an Int64SliceFlag, returns an error if
+// the flag is not defined
+func (c *Context) GetInt64Slice(name string) ([]int64, error) {
+	fs, err := c.definedFlagSet(name)
+	if err != nil {
+		return nil, err
+	}
+	return lookupInt64Slice(name, fs), nil
 }
 
 func lookupInt64Slice(name string, set *flag.FlagSet) []int64 {
@@ -47,7 +47,11 @@ func newIntSlice(value []int, p *[]int) *IntSlice {
 // SetInt directly adds an integer to the list of values
 func (i *IntSlice) SetInt(value int) {
 	if !i.hasBeenSet {
-		i.value = &[]int{}
+		if i.value == nil {
+			i.value = &[]int{}
+		} else {
+			*i.value = []int{}
+		}
 		i.hasBeenSet = true
 	}
 
@@ -57,7 +61,11 @@ func (i *IntSlice) SetInt(value int) {
 // Set parses the value into an integer and appends it to the list of values
 func (i *IntSlice) Set(value string) error {
 	if !i.hasBeenSet {
-		i.value = &[]int{}
+		if i.value == nil {
+			i.value = &[]int{}
+		} else {
+			*i.value = []int{}
+		}
 		i.hasBeenSet = true
 	}
 
@@ -130,16 +138,23 @@ func (i *IntSlice) Get() interface{} {
 
 // IntSliceFlag is a flag with type *IntSlice
 type IntSliceFlag struct {
-	Name        string
-	Aliases     []string
-	Usage       string
-	EnvVars     []string
-	FilePath    string
-	Required    bool
-	Hidden      bool
-	Value       *IntSlice
-	DefaultText string
-	HasBeenSet  bool
+	Name          string
+	Aliases       []string
+	Usage         string
+	EnvVars       []string
+	FilePath      string
+	Required      bool
+	Hidden        bool
+	Value         *IntSlice
+	DefaultText   string
+	HasBeenSet    bool
+	Validator     ValidatorFunc
+	Deprecated    string
+	ReplacedBy    string
+	Action        FlagActionFunc
+	NoAutoEnv     bool
+	ValueFromFile bool
+	Persistent    bool
 }
 
 // IsSet returns whether or not the flag has been set through env or file
@@ -185,7 +200,12 @@ func (f *IntSliceFlag) GetValue() string {
 // Apply populates the flag given the flag set and environment
 func (f *IntSliceFlag) Apply(set *flag.FlagSet) error {
 	if val, ok := flagFromEnvOrFile(f.EnvVars, f.FilePath); ok {
-		f.Value = &IntSlice{}
+		// parse into the existing value to keep its destination, the
+		// value replacing the default
+		if f.Value == nil {
+			f.Value = &IntSlice{}
+		}
+		f.Value.hasBeenSet = false
 
 		for _, s := range strings.Split(val, ",") {
 			if err := f.Value.Set(strings.TrimSpace(s)); err != nil {
@@ -264,12 +284,22 @@ func (a *App) IntSliceP(name, alias string, value []int, usage, env string) *[]i
 // IntSlice looks up the value of a local IntSliceFlag, returns
 // nil if not found
 func (c *Context) IntSlice(name string) []int {
-	if fs := lookupFlagSet(name, c); fs != nil {
-		return lookupIntSlice(name, c.flagSet)
+	if fs := c.strictFlagSet(name); fs != nil {
+		return lookupIntSlice(name, fs)
 	}
 	return nil
 }
 
+// GetIntSlice looks up the value of an IntSliceFlag, returns an error if the
+// flag is not defined
+func (c *Context) GetIntSlice(name string) ([]int, error) {
+	fs, err := c.definedFlagSet(name)
+	if err != nil {
+		return nil, err
+	}
+	return lookupIntSlice(name, fs), nil
+}
+
 func lookupIntSlice(name string, set *flag.FlagSet) []int {
 	f := set.Lookup(name)
 	if f != nil {
@@ -0,0 +1,304 @@
+// Copyright 2020 The vine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cli
+
+import (
+	"flag"
+	"fmt"
+	"net"
+	"strings"
+)
+
+// ipValue wraps a net.IP to satisfy flag.Value
+type ipValue struct {
+	value *net.IP
+}
+
+func newIPValue(value net.IP, p *net.IP) *ipValue {
+	if p == nil {
+		p = new(net.IP)
+	}
+	*p = value
+	return &ipValue{value: p}
+}
+
+// Set parses the value as an IPv4 or IPv6 address
+func (i *ipValue) Set(value string) error {
+	ip, err := parseIP(value)
+	if err != nil {
+		return err
+	}
+	*i.value = ip
+	return nil
+}
+
+// String returns a readable representation of this value
+func (i *ipValue) String() string {
+	if i.value == nil || *i.value == nil {
+		return ""
+	}
+	return i.value.String()
+}
+
+// Get returns the IP address
+func (i *ipValue) Get() interface{} {
+	return *i.value
+}
+
+func parseIP(value string) (net.IP, error) {
+	ip := net.ParseIP(strings.TrimSpace(value))
+	if ip == nil {
+		return nil, fmt.Errorf("invalid IP address %q", value)
+	}
+	return ip, nil
+}
+
+func validateIP(value string) error {
+	_, err := parseIP(value)
+	return err
+}
+
+// IPFlag is a flag with type net.IP
+type IPFlag struct {
+	Name          string
+	Aliases       []string
+	Usage         string
+	EnvVars       []string
+	FilePath      string
+	Required      bool
+	Hidden        bool
+	Value         net.IP
+	DefaultText   string
+	Destination   *net.IP
+	HasBeenSet    bool
+	Validator     ValidatorFunc
+	Deprecated    string
+	ReplacedBy    string
+	Action        FlagActionFunc
+	NoAutoEnv     bool
+	ValueFromFile bool
+	Persistent    bool
+}
+
+// IsSet returns whether or not the flag has been set through env or file
+func (f *IPFlag) IsSet() bool {
+	return f.HasBeenSet
+}
+
+// String returns a readable representation of this value
+// (for usage defaults)
+func (f *IPFlag) String() string {
+	return FlagStringer(f)
+}
+
+// Names returns the names of the flag
+func (f *IPFlag) Names() []string {
+	return flagNames(f.Name, f.Aliases)
+}
+
+// IsRequired returns whether or not the flag is required
+func (f *IPFlag) IsRequired() bool {
+	return f.Required
+}
+
+// TakesValue returns true of the flag takes a value, otherwise false
+func (f *IPFlag) TakesValue() bool {
+	return true
+}
+
+// GetUsage returns the usage string for the flag
+func (f *IPFlag) GetUsage() string {
+	return f.Usage
+}
+
+// GetValue returns the flags value as string representation and an empty
+// string if the flag takes no value at all.
+func (f *IPFlag) GetValue() string {
+	if f.Value != nil {
+		return f.Value.String()
+	}
+	return ""
+}
+
+// Apply populates the flag given the flag set and environment
+func (f *IPFlag) Apply(set *flag.FlagSet) error {
+	if val, ok := flagFromEnvOrFile(f.EnvVars, f.FilePath); ok {
+		if val != "" {
+			ip, err := parseIP(val)
+			if err != nil {
+				return fmt.Errorf("could not parse %q as IP value for flag %s: %s", val, f.Name, err)
+			}
+
+			f.Value = ip
+			f.HasBeenSet = true
+		}
+	}
+
+	value := newIPValue(f.Value, f.Destination)
+	for _, name := range f.Names() {
+		set.Var(value, name, f.Usage)
+	}
+
+	return nil
+}
+
+// IP looks up the value of a local IPFlag, returns
+// nil if not found
+func (c *Context) IP(name string) net.IP {
+	if fs := c.strictFlagSet(name); fs != nil {
+		return lookupIP(name, fs)
+	}
+	return nil
+}
+
+// GetIP looks up the value of an IPFlag, returns an error if the
+// flag is not defined
+func (c *Context) GetIP(name string) (net.IP, error) {
+	fs, err := c.definedFlagSet(name)
+	if err != nil {
+		return nil, err
+	}
+	return lookupIP(name, fs), nil
+}
+
+func lookupIP(name string, set *flag.FlagSet) net.IP {
+	f := set.Lookup(name)
+	if f != nil {
+		if value, ok := f.Value.(*ipValue); ok {
+			return *value.value
+		}
+	}
+	return nil
+}
+
+// IPSliceFlag is a flag with type *StringSlice whose items are IP addresses
+type IPSliceFlag struct {
+	Name          string
+	Aliases       []string
+	Usage         string
+	EnvVars       []string
+	FilePath      string
+	Required      bool
+	Hidden        bool
+	Value         *StringSlice
+	DefaultText   string
+	HasBeenSet    bool
+	Validator     ValidatorFunc
+	Deprecated    string
+	ReplacedBy    string
+	Action        FlagActionFunc
+	NoAutoEnv     bool
+	ValueFromFile bool
+	Persistent    bool
+}
+
+// IsSet returns whether or not the flag has been set through env or file
+func (f *IPSliceFlag) IsSet() bool {
+	return f.HasBeenSet
+}
+
+// String returns a readable representation of this value
+// (for usage defaults)
+func (f *IPSliceFlag) String() string {
+	return FlagStringer(f)
+}
+
+// Names returns the names of the flag
+func (f *IPSliceFlag) Names() []string {
+	return flagNames(f.Name, f.Aliases)
+}
+
+// IsRequired returns whether or not the flag is required
+func (f *IPSliceFlag) IsRequired() bool {
+	return f.Required
+}
+
+// TakesValue returns true of the flag takes a value, otherwise false
+func (f *IPSliceFlag) TakesValue() bool {
+	return true
+}
+
+// GetUsage returns the usage string for the flag
+func (f *IPSliceFlag) GetUsage() string {
+	return f.Usage
+}
+
+// GetValue returns the flags value as string representation and an empty
+// string if the flag takes no value at all.
+func (f *IPSliceFlag) GetValue() string {
+	if f.Value != nil {
+		return f.Value.String()
+	}
+	return ""
+}
+
+// Apply populates the flag given the flag set and environment
+func (f *IPSliceFlag) Apply(set *flag.FlagSet) error {
+	if val, ok := flagFromEnvOrFile(f.EnvVars, f.FilePath); ok {
+		value := &validatedSlice{validate: validateIP, slice: &StringSlice{}}
+
+		for _, s := range strings.Split(val, ",") {
+			if err := value.Set(strings.TrimSpace(s)); err != nil {
+				return fmt.Errorf("could not parse %q as IP value for flag %s: %s", val, f.Name, err)
+			}
+		}
+
+		f.Value = value.slice
+		f.HasBeenSet = true
+	}
+
+	if f.Value == nil {
+		f.Value = &StringSlice{}
+	}
+	value := &validatedSlice{validate: validateIP, slice: f.Value}
+	for _, name := range f.Names() {
+		set.Var(value, name, f.Usage)
+	}
+
+	return nil
+}
+
+// IPSlice looks up the value of a local IPSliceFlag, returns
+// nil if not found
+func (c *Context) IPSlice(name string) []net.IP {
+	if fs := c.strictFlagSet(name); fs != nil {
+		return lookupIPSlice(name, fs)
+	}
+	return nil
+}
+
+// GetIPSlice looks up the value of an IPSliceFlag, returns an error if the
+// flag is not defined
+func (c *Context) GetIPSlice(name string) ([]net.IP, error) {
+	fs, err := c.definedFlagSet(name)
+	if err != nil {
+		return nil, err
+	}
+	return lookupIPSlice(name, fs), nil
+}
+
+func lookupIPSlice(name string, set *flag.FlagSet) []net.IP {
+	items := lookupValidatedSlice(name, set)
+	if items == nil {
+		return nil
+	}
+	ips := make([]net.IP, 0, len(items))
+	for _, item := range items {
+		if ip, err := parseIP(item); err == nil {
+			ips = append(ips, ip)
+		}
+	}
+	return ips
+}
@@ -0,0 +1,308 @@
+// Copyright 2020 The vine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cli
+
+import (
+	"flag"
+	"fmt"
+	"net"
+	"strings"
+)
+
+// ipNetValue wraps a net.IPNet to satisfy flag.Value
+type ipNetValue struct {
+	value *net.IPNet
+}
+
+func newIPNetValue(value *net.IPNet, p *net.IPNet) *ipNetValue {
+	if p == nil {
+		p = new(net.IPNet)
+	}
+	if value != nil {
+		*p = *value
+	}
+	return &ipNetValue{value: p}
+}
+
+// Set parses the value as a network in CIDR notation, i.e. 10.0.0.0/8
+func (i *ipNetValue) Set(value string) error {
+	n, err := parseIPNet(value)
+	if err != nil {
+		return err
+	}
+	*i.value = *n
+	return nil
+}
+
+// String returns a readable representation of this value
+func (i *ipNetValue) String() string {
+	if i.value == nil || i.value.IP == nil {
+		return ""
+	}
+	return i.value.String()
+}
+
+// Get returns the network
+func (i *ipNetValue) Get() interface{} {
+	return i.value
+}
+
+func parseIPNet(value string) (*net.IPNet, error) {
+	_, n, err := net.ParseCIDR(strings.TrimSpace(value))
+	if err != nil {
+		return nil, fmt.Errorf("invalid CIDR address %q", value)
+	}
+	return n, nil
+}
+
+func validateIPNet(value string) error {
+	_, err := parseIPNet(value)
+	return err
+}
+
+// IPNetFlag is a flag with type *net.IPNet given in CIDR notation
+type IPNetFlag struct {
+	Name          string
+	Aliases       []string
+	Usage         string
+	EnvVars       []string
+	FilePath      string
+	Required      bool
+	Hidden        bool
+	Value         *net.IPNet
+	DefaultText   string
+	Destination   *net.IPNet
+	HasBeenSet    bool
+	Validator     ValidatorFunc
+	Deprecated    string
+	ReplacedBy    string
+	Action        FlagActionFunc
+	NoAutoEnv     bool
+	ValueFromFile bool
+	Persistent    bool
+}
+
+// IsSet returns whether or not the flag has been set through env or file
+func (f *IPNetFlag) IsSet() bool {
+	return f.HasBeenSet
+}
+
+// String returns a readable representation of this value
+// (for usage defaults)
+func (f *IPNetFlag) String() string {
+	return FlagStringer(f)
+}
+
+// Names returns the names of the flag
+func (f *IPNetFlag) Names() []string {
+	return flagNames(f.Name, f.Aliases)
+}
+
+// IsRequired returns whether or not the flag is required
+func (f *IPNetFlag) IsRequired() bool {
+	return f.Required
+}
+
+// TakesValue returns true of the flag takes a value, otherwise false
+func (f *IPNetFlag) TakesValue() bool {
+	return true
+}
+
+// GetUsage returns the usage string for the flag
+func (f *IPNetFlag) GetUsage() string {
+	return f.Usage
+}
+
+// GetValue returns the flags value as string representation and an empty
+// string if the flag takes no value at all.
+func (f *IPNetFlag) GetValue() string {
+	if f.Value != nil {
+		return f.Value.String()
+	}
+	return ""
+}
+
+// Apply populates the flag given the flag set and environment
+func (f *IPNetFlag) Apply(set *flag.FlagSet) error {
+	if val, ok := flagFromEnvOrFile(f.EnvVars, f.FilePath); ok {
+		if val != "" {
+			n, err := parseIPNet(val)
+			if err != nil {
+				return fmt.Errorf("could not parse %q as CIDR value for flag %s: %s", val, f.Name, err)
+			}
+
+			f.Value = n
+			f.HasBeenSet = true
+		}
+	}
+
+	value := newIPNetValue(f.Value, f.Destination)
+	for _, name := range f.Names() {
+		set.Var(value, name, f.Usage)
+	}
+
+	return nil
+}
+
+// IPNet looks up the value of a local IPNetFlag, returns
+// nil if not found
+func (c *Context) IPNet(name string) *net.IPNet {
+	if fs := c.strictFlagSet(name); fs != nil {
+		return lookupIPNet(name, fs)
+	}
+	return nil
+}
+
+// GetIPNet looks up the value of an IPNetFlag, returns an error if the
+// flag is not defined
+func (c *Context) GetIPNet(name string) (*net.IPNet, error) {
+	fs, err := c.definedFlagSet(name)
+	if err != nil {
+		return nil, err
+	}
+	return lookupIPNet(name, fs), nil
+}
+
+func lookupIPNet(name string, set *flag.FlagSet) *net.IPNet {
+	f := set.Lookup(name)
+	if f != nil {
+		if value, ok := f.Value.(*ipNetValue); ok && value.value.IP != nil {
+			n := *value.value
+			return &n
+		}
+	}
+	return nil
+}
+
+// IPNetSliceFlag is a flag with type *StringSlice whose items are
+// networks in CIDR notation
+type IPNetSliceFlag struct {
+	Name          string
+	Aliases       []string
+	Usage         string
+	EnvVars       []string
+	FilePath      string
+	Required      bool
+	Hidden        bool
+	Value         *StringSlice
+	DefaultText   string
+	HasBeenSet    bool
+	Validator     ValidatorFunc
+	Deprecated    string
+	ReplacedBy    string
+	Action        FlagActionFunc
+	NoAutoEnv     bool
+	ValueFromFile bool
+	Persistent    bool
+}
+
+// IsSet returns whether or not the flag has been set through env or file
+func (f *IPNetSliceFlag) IsSet() bool {
+	return f.HasBeenSet
+}
+
+// String returns a readable representation of this value
+// (for usage defaults)
+func (f *IPNetSliceFlag) String() string {
+	return FlagStringer(f)
+}
+
+// Names returns the names of the flag
+func (f *IPNetSliceFlag) Names() []string {
+	return flagNames(f.Name, f.Aliases)
+}
+
+// IsRequired returns whether or not the flag is required
+func (f *IPNetSliceFlag) IsRequired() bool {
+	return f.Required
+}
+
+// TakesValue returns true of the flag takes a value, otherwise false
+func (f *IPNetSliceFlag) TakesValue() bool {
+	return true
+}
+
+// GetUsage returns the usage string for the flag
+func (f *IPNetSliceFlag) GetUsage() string {
+	return f.Usage
+}
+
+// GetValue returns the flags value as string representation and an empty
+// string if the flag takes no value at all.
+func (f *IPNetSliceFlag) GetValue() string {
+	if f.Value != nil {
+		return f.Value.String()
+	}
+	return ""
+}
+
+// Apply populates the flag given the flag set and environment
+func (f *IPNetSliceFlag) Apply(set *flag.FlagSet) error {
+	if val, ok := flagFromEnvOrFile(f.EnvVars, f.FilePath); ok {
+		value := &validatedSlice{validate: validateIPNet, slice: &StringSlice{}}
+
+		for _, s := range strings.Split(val, ",") {
+			if err := value.Set(strings.TrimSpace(s)); err != nil {
+				return fmt.Errorf("could not parse %q as CIDR value for flag %s: %s", val, f.Name, err)
+			}
+		}
+
+		f.Value = value.slice
+		f.HasBeenSet = true
+	}
+
+	if f.Value == nil {
+		f.Value = &StringSlice{}
+	}
+	value := &validatedSlice{validate: validateIPNet, slice: f.Value}
+	for _, name := range f.Names() {
+		set.Var(value, name, f.Usage)
+	}
+
+	return nil
+}
+
+// IPNetSlice looks up the value of a local IPNetSliceFlag, returns
+// nil if not found
+func (c *Context) IPNetSlice(name string) []*net.IPNet {
+	if fs := c.strictFlagSet(name); fs != nil {
+		return lookupIPNetSlice(name, fs)
+	}
+	return nil
+}
+
+// GetIPNetSlice looks up the value of an IPNetSliceFlag, returns an error if the
+// flag is not defined
+func (c *Context) GetIPNetSlice(name string) ([]*net.IPNet, error) {
+	fs, err := c.definedFlagSet(name)
+	if err != nil {
+		return nil, err
+	}
+	return lookupIPNetSlice(name, fs), nil
+}
+
+func lookupIPNetSlice(name string, set *flag.FlagSet) []*net.IPNet {
+	items := lookupValidatedSlice(name, set)
+	if items == nil {
+		return nil
+	}
+	nets := make([]*net.IPNet, 0, len(items))
+	for _, item := range items {
+		if n, err := parseIPNet(item); err == nil {
+			nets = append(nets, n)
+		}
+	}
+	return nets
+}
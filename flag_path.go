@@ -17,18 +17,25 @@ package cli
 import "flag"
 
 type PathFlag struct {
-	Name        string
-	Aliases     []string
-	Usage       string
-	EnvVars     []string
-	FilePath    string
-	Required    bool
-	Hidden      bool
-	TakesFile   bool
-	Value       string
-	DefaultText string
-	Destination *string
-	HasBeenSet  bool
+	Name          string
+	Aliases       []string
+	Usage         string
+	EnvVars       []string
+	FilePath      string
+	Required      bool
+	Hidden        bool
+	TakesFile     bool
+	Value         string
+	DefaultText   string
+	Destination   *string
+	HasBeenSet    bool
+	Validator     ValidatorFunc
+	Deprecated    string
+	ReplacedBy    string
+	Action        FlagActionFunc
+	NoAutoEnv     bool
+	ValueFromFile bool
+	Persistent    bool
 }
 
 // IsSet returns whether or not the flag has been set through env or file
@@ -145,13 +152,23 @@ func (a *App) PathP(name, alias string, value string, usage, env string) *string
 // Path looks up the value of a local PathFlag, returns
 // "" if not found
 func (c *Context) Path(name string) string {
-	if fs := lookupFlagSet(name, c); fs != nil {
+	if fs := c.strictFlagSet(name); fs != nil {
 		return lookupPath(name, fs)
 	}
 
 	return ""
 }
 
+// GetPath looks up the value of a PathFlag, returns an error if the flag is
+// not defined
+func (c *Context) GetPath(name string) (string, error) {
+	fs, err := c.definedFlagSet(name)
+	if err != nil {
+		return "", err
+	}
+	return lookupPath(name, fs), nil
+}
+
 func lookupPath(name string, set *flag.FlagSet) string {
 	f := set.Lookup(name)
 	if f != nil {
@@ -20,18 +20,25 @@ import (
 
 // StringFlag is a flag with type string
 type StringFlag struct {
-	Name        string
-	Aliases     []string
-	Usage       string
-	EnvVars     []string
-	FilePath    string
-	Required    bool
-	Hidden      bool
-	TakesFile   bool
-	Value       string
-	DefaultText string
-	Destination *string
-	HasBeenSet  bool
+	Name          string
+	Aliases       []string
+	Usage         string
+	EnvVars       []string
+	FilePath      string
+	Required      bool
+	Hidden        bool
+	TakesFile     bool
+	Value         string
+	DefaultText   string
+	Destination   *string
+	HasBeenSet    bool
+	Validator     ValidatorFunc
+	Deprecated    string
+	ReplacedBy    string
+	Action        FlagActionFunc
+	NoAutoEnv     bool
+	ValueFromFile bool
+	Persistent    bool
 }
 
 // IsSet returns whether or not the flag has been set through env or file
@@ -148,12 +155,22 @@ func (a *App) StringP(name, alias string, value string, usage, env string) *stri
 // String looks up the value of a local StringFlag, returns
 // "" if not found
 func (c *Context) String(name string) string {
-	if fs := lookupFlagSet(name, c); fs != nil {
+	if fs := c.strictFlagSet(name); fs != nil {
 		return lookupString(name, fs)
 	}
 	return ""
 }
 
+// GetString looks up the value of a StringFlag, returns an error if the
+// flag is not defined
+func (c *Context) GetString(name string) (string, error) {
+	fs, err := c.definedFlagSet(name)
+	if err != nil {
+		return "", err
+	}
+	return lookupString(name, fs), nil
+}
+
 func lookupString(name string, set *flag.FlagSet) string {
 	f := set.Lookup(name)
 	if f != nil {
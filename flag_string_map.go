@@ -0,0 +1,312 @@
+// Copyright 2020 The vine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cli
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// defaultMapSeparator separates the key from the value of a StringMap item
+const defaultMapSeparator = "="
+
+// StringMap wraps a map[string]string to satisfy flag.Value
+type StringMap struct {
+	value      *map[string]string
+	separator  string
+	hasBeenSet bool
+}
+
+// NewStringMap creates a *StringMap with default values
+func NewStringMap(defaults map[string]string) *StringMap {
+	return newStringMap(defaults, nil)
+}
+
+func newStringMap(value map[string]string, p *map[string]string) *StringMap {
+	m := new(StringMap)
+	if p == nil {
+		p = &map[string]string{}
+	}
+	m.value = p
+	*m.value = value
+	return m
+}
+
+// Set adds the key-value pairs of value, i.e. "a=1,b=2", to the map
+func (s *StringMap) Set(value string) error {
+	if !s.hasBeenSet {
+		*s.value = map[string]string{}
+		s.hasBeenSet = true
+	}
+
+	if strings.HasPrefix(value, slPfx) {
+		// Deserializing assumes overwrite
+		m := map[string]string{}
+		_ = json.Unmarshal([]byte(strings.Replace(value, slPfx, "", 1)), &m)
+		*s.value = m
+		return nil
+	}
+
+	tmp, err := stringMapConv(value, s.sep())
+	if err != nil {
+		return err
+	}
+
+	for k, v := range tmp {
+		(*s.value)[k] = v
+	}
+
+	return nil
+}
+
+func (s *StringMap) sep() string {
+	if s.separator == "" {
+		return defaultMapSeparator
+	}
+	return s.separator
+}
+
+func stringMapConv(val, sep string) (map[string]string, error) {
+	out := map[string]string{}
+	// Empty string would cause a map with one (empty) entry
+	if len(strings.TrimSpace(val)) == 0 {
+		return out, nil
+	}
+	for _, item := range strings.Split(val, ",") {
+		kv := strings.SplitN(item, sep, 2)
+		if len(kv) != 2 {
+			return nil, fmt.Errorf("%q is not in key%svalue form", strings.TrimSpace(item), sep)
+		}
+		out[strings.TrimSpace(kv[0])] = strings.TrimSpace(kv[1])
+	}
+	return out, nil
+}
+
+// String returns a readable representation of this value (for usage defaults)
+func (s *StringMap) String() string {
+	if s.value == nil {
+		return ""
+	}
+	return strings.Join(s.items(), ",")
+}
+
+// items returns the key-value pairs of the map sorted by key
+func (s *StringMap) items() []string {
+	var out []string
+	for k, v := range *s.value {
+		out = append(out, k+s.sep()+v)
+	}
+	sort.Strings(out)
+	return out
+}
+
+// Serialize allows StringMap to fulfill Serializer
+func (s *StringMap) Serialize() string {
+	jsonBytes, _ := json.Marshal(*s.value)
+	return fmt.Sprintf("%s%s", slPfx, string(jsonBytes))
+}
+
+// Value returns the map of strings set by this flag
+func (s *StringMap) Value() map[string]string {
+	if s.value == nil {
+		s.value = &map[string]string{}
+	}
+	return *s.value
+}
+
+// Get returns the map of strings set by this flag
+func (s *StringMap) Get() interface{} {
+	return *s
+}
+
+// StringMapFlag is a flag with type *StringMap
+type StringMapFlag struct {
+	Name          string
+	Aliases       []string
+	Usage         string
+	EnvVars       []string
+	FilePath      string
+	Required      bool
+	Hidden        bool
+	Value         *StringMap
+	DefaultText   string
+	HasBeenSet    bool
+	Validator     ValidatorFunc
+	Deprecated    string
+	ReplacedBy    string
+	Action        FlagActionFunc
+	NoAutoEnv     bool
+	ValueFromFile bool
+	Persistent    bool
+	// Separator separates the key from the value, defaults to "="
+	Separator string
+}
+
+// IsSet returns whether or not the flag has been set through env or file
+func (f *StringMapFlag) IsSet() bool {
+	return f.HasBeenSet
+}
+
+// String returns a readable representation of this value
+// (for usage defaults)
+func (f *StringMapFlag) String() string {
+	return FlagStringer(f)
+}
+
+// Names returns the names of the flag
+func (f *StringMapFlag) Names() []string {
+	return flagNames(f.Name, f.Aliases)
+}
+
+// IsRequired returns whether or not the flag is required
+func (f *StringMapFlag) IsRequired() bool {
+	return f.Required
+}
+
+// TakesValue returns true of the flag takes a value, otherwise false
+func (f *StringMapFlag) TakesValue() bool {
+	return true
+}
+
+// GetUsage returns the usage string for the flag
+func (f *StringMapFlag) GetUsage() string {
+	return f.Usage
+}
+
+// GetValue returns the flags value as string representation and an empty
+// string if the flag takes no value at all.
+func (f *StringMapFlag) GetValue() string {
+	if f.Value != nil {
+		return f.Value.String()
+	}
+	return ""
+}
+
+// Apply populates the flag given the flag set and environment
+func (f *StringMapFlag) Apply(set *flag.FlagSet) error {
+	if val, ok := flagFromEnvOrFile(f.EnvVars, f.FilePath); ok {
+		// parse into the existing value to keep its destination, the
+		// value replacing the default
+		if f.Value == nil {
+			f.Value = &StringMap{value: &map[string]string{}}
+		}
+		f.Value.separator = f.Separator
+		f.Value.hasBeenSet = false
+
+		if err := f.Value.Set(val); err != nil {
+			return fmt.Errorf("could not parse %q as string map value for flag %s: %s", val, f.Name, err)
+		}
+
+		f.HasBeenSet = true
+	}
+
+	if f.Value == nil {
+		f.Value = NewStringMap(map[string]string{})
+	}
+	f.Value.separator = f.Separator
+
+	for _, name := range f.Names() {
+		set.Var(f.Value, name, f.Usage)
+	}
+
+	return nil
+}
+
+func (a *App) stringMapVar(p *map[string]string, name, alias string, value map[string]string, usage, env string) {
+	if a.Flags == nil {
+		a.Flags = make([]Flag, 0)
+	}
+	flag := &StringMapFlag{
+		Name:  name,
+		Usage: usage,
+		Value: newStringMap(value, p),
+	}
+	if alias != "" {
+		flag.Aliases = []string{alias}
+	}
+	if env != "" {
+		flag.EnvVars = []string{env}
+	}
+	a.Flags = append(a.Flags, flag)
+}
+
+// StringMapVar defines a map[string]string flag with specified name, default value, usage string and env string.
+// The argument p points to a map[string]string variable in which to store the value of the flag.
+func (a *App) StringMapVar(p *map[string]string, name string, value map[string]string, usage, env string) {
+	a.stringMapVar(p, name, "", value, usage, env)
+}
+
+// StringMapVarP is like StringMapVar, but accepts a shorthand letter that can be used after a single dash.
+func (a *App) StringMapVarP(p *map[string]string, name, alias string, value map[string]string, usage, env string) {
+	a.stringMapVar(p, name, alias, value, usage, env)
+}
+
+// StringMapVar defines a map[string]string flag with specified name, default value, usage string and env string.
+// The argument p points to a map[string]string variable in which to store the value of the flag.
+func StringMapVar(p *map[string]string, name string, value map[string]string, usage, env string) {
+	CommandLine.StringMapVar(p, name, value, usage, env)
+}
+
+// StringMapVarP is like StringMapVar, but accepts a shorthand letter that can be used after a single dash.
+func StringMapVarP(p *map[string]string, name, alias string, value map[string]string, usage, env string) {
+	CommandLine.StringMapVarP(p, name, alias, value, usage, env)
+}
+
+// StringMap defines a map[string]string flag with specified name, default value, usage string and env string.
+// The return value is the address of a map[string]string variable that stores the value of the flag.
+func (a *App) StringMap(name string, value map[string]string, usage, env string) *map[string]string {
+	p := new(map[string]string)
+	a.StringMapVar(p, name, value, usage, env)
+	return p
+}
+
+// StringMapP is like StringMap, but accepts a shorthand letter that can be used after a single dash.
+func (a *App) StringMapP(name, alias string, value map[string]string, usage, env string) *map[string]string {
+	p := new(map[string]string)
+	a.StringMapVarP(p, name, alias, value, usage, env)
+	return p
+}
+
+// StringMap looks up the value of a local StringMapFlag, returns
+// nil if not found
+func (c *Context) StringMap(name string) map[string]string {
+	if fs := c.strictFlagSet(name); fs != nil {
+		return lookupStringMap(name, fs)
+	}
+	return nil
+}
+
+// GetStringMap looks up the value of a StringMapFlag, returns an error if the
+// flag is not defined
+func (c *Context) GetStringMap(name string) (map[string]string, error) {
+	fs, err := c.definedFlagSet(name)
+	if err != nil {
+		return nil, err
+	}
+	return lookupStringMap(name, fs), nil
+}
+
+func lookupStringMap(name string, set *flag.FlagSet) map[string]string {
+	f := set.Lookup(name)
+	if f != nil {
+		if value, ok := f.Value.(*StringMap); ok {
+			return value.Value()
+		}
+	}
+	return nil
+}
@@ -45,7 +45,12 @@ func newStringSlice(value []string, p *[]string) *StringSlice {
 // Set appends the string value to the list of values
 func (s *StringSlice) Set(value string) error {
 	if !s.hasBeenSet {
-		s.value = &[]string{}
+		// reset in place, the value may be bound to a destination
+		if s.value == nil {
+			s.value = &[]string{}
+		} else {
+			*s.value = []string{}
+		}
 		s.hasBeenSet = true
 	}
 
@@ -111,19 +116,77 @@ func (s *StringSlice) Get() interface{} {
 	return *s
 }
 
+// validatedSlice wraps a StringSlice checking every item set on it to
+// satisfy flag.Value
+type validatedSlice struct {
+	validate func(string) error
+	slice    *StringSlice
+}
+
+// Set appends the value to the list of values if every item of it is valid
+func (v *validatedSlice) Set(value string) error {
+	if !strings.HasPrefix(value, slPfx) {
+		items, err := stringSliceConv(value)
+		if err != nil {
+			return err
+		}
+		for _, item := range items {
+			if err := v.validate(item); err != nil {
+				return err
+			}
+		}
+	}
+
+	return v.slice.Set(value)
+}
+
+// String returns a readable representation of this value (for usage defaults)
+func (v *validatedSlice) String() string {
+	return v.slice.String()
+}
+
+// Serialize allows validatedSlice to fulfill Serializer
+func (v *validatedSlice) Serialize() string {
+	return v.slice.Serialize()
+}
+
+// Get returns the slice of strings set by this flag
+func (v *validatedSlice) Get() interface{} {
+	return v.slice.Value()
+}
+
+// lookupValidatedSlice returns the items of a slice flag whose items are
+// checked when set
+func lookupValidatedSlice(name string, set *flag.FlagSet) []string {
+	f := set.Lookup(name)
+	if f != nil {
+		if value, ok := f.Value.(*validatedSlice); ok {
+			return value.slice.Value()
+		}
+	}
+	return nil
+}
+
 // StringSliceFlag is a flag with type *StringSlice
 type StringSliceFlag struct {
-	Name        string
-	Aliases     []string
-	Usage       string
-	EnvVars     []string
-	FilePath    string
-	Required    bool
-	Hidden      bool
-	TakesFile   bool
-	Value       *StringSlice
-	DefaultText string
-	HasBeenSet  bool
+	Name          string
+	Aliases       []string
+	Usage         string
+	EnvVars       []string
+	FilePath      string
+	Required      bool
+	Hidden        bool
+	TakesFile     bool
+	Value         *StringSlice
+	DefaultText   string
+	HasBeenSet    bool
+	Validator     ValidatorFunc
+	Deprecated    string
+	ReplacedBy    string
+	Action        FlagActionFunc
+	NoAutoEnv     bool
+	ValueFromFile bool
+	Persistent    bool
 }
 
 // IsSet returns whether or not the flag has been set through env or file
@@ -169,7 +232,12 @@ func (f *StringSliceFlag) GetValue() string {
 // Apply populates the flag given the flag set and environment
 func (f *StringSliceFlag) Apply(set *flag.FlagSet) error {
 	if val, ok := flagFromEnvOrFile(f.EnvVars, f.FilePath); ok {
-		f.Value = &StringSlice{}
+		// parse into the existing value to keep its destination, the
+		// value replacing the default
+		if f.Value == nil {
+			f.Value = &StringSlice{}
+		}
+		f.Value.hasBeenSet = false
 
 		for _, s := range strings.Split(val, ",") {
 			if err := f.Value.Set(strings.TrimSpace(s)); err != nil {
@@ -248,12 +316,22 @@ func (a *App) StringSliceP(name, alias string, value []string, usage, env string
 // StringSlice looks up the value of a local StringSliceFlag, returns
 // nil if not found
 func (c *Context) StringSlice(name string) []string {
-	if fs := lookupFlagSet(name, c); fs != nil {
+	if fs := c.strictFlagSet(name); fs != nil {
 		return lookupStringSlice(name, fs)
 	}
 	return nil
 }
 
+// GetStringSlice looks up the value of a StringSliceFlag, returns an error if the
+// flag is not defined
+func (c *Context) GetStringSlice(name string) ([]string, error) {
+	fs, err := c.definedFlagSet(name)
+	if err != nil {
+		return nil, err
+	}
+	return lookupStringSlice(name, fs), nil
+}
+
 func lookupStringSlice(name string, set *flag.FlagSet) []string {
 	f := set.Lookup(name)
 	if f != nil {
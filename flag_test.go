@@ -15,10 +15,13 @@
 package cli
 
 import (
+	"errors"
 	"flag"
 	"fmt"
 	"io"
 	"io/ioutil"
+	"net"
+	"net/url"
 	"os"
 	"reflect"
 	"regexp"
@@ -58,6 +61,30 @@ func TestBoolFlagApply_SetsAllNames(t *testing.T) {
 	expect(t, v, true)
 }
 
+func TestBoolFlagHelpOutput_Negatable(t *testing.T) {
+	fl := &BoolFlag{Name: "color", Aliases: []string{"c"}, Usage: "colorize output", Value: true, Negatable: true}
+	output := fl.String()
+
+	expect(t, output, "--[no-]color, -c\tcolorize output (default: true)")
+}
+
+func TestBoolFlagApply_Negatable(t *testing.T) {
+	v := true
+	fl := BoolFlag{Name: "color", Aliases: []string{"colour"}, Value: true, Destination: &v, Negatable: true}
+	set := flag.NewFlagSet("test", 0)
+	_ = fl.Apply(set)
+
+	p := &argParser{set: set, ip: &Command{}}
+	err := p.parse([]string{"--no-colour"})
+	expect(t, err, nil)
+	expect(t, v, false)
+	expect(t, set.Lookup("colour").Value.String(), "false")
+
+	err = p.parse([]string{"--no-color=false"})
+	expect(t, err, nil)
+	expect(t, v, true)
+}
+
 func TestFlagsFromEnv(t *testing.T) {
 	newSetIntSlice := func(defaults ...int) IntSlice {
 		s := NewIntSlice(defaults...)
@@ -438,6 +465,149 @@ func TestIntFlagApply_SetsAllNames(t *testing.T) {
 	expect(t, v, 5)
 }
 
+var countFlagTests = []struct {
+	name     string
+	expected string
+}{
+	{"verbose", "--verbose\t(default: 0)"},
+	{"v", "-v\t(default: 0)"},
+}
+
+func TestCountFlagHelpOutput(t *testing.T) {
+	for _, test := range countFlagTests {
+		fl := &CountFlag{Name: test.name}
+		output := fl.String()
+
+		if output != test.expected {
+			t.Errorf("%s does not match %s", output, test.expected)
+		}
+	}
+}
+
+func TestCountFlagApply_SetsAllNames(t *testing.T) {
+	v := 0
+	fl := CountFlag{Name: "verbose", Aliases: []string{"v"}, Destination: &v}
+	set := flag.NewFlagSet("test", 0)
+	_ = fl.Apply(set)
+
+	err := parseIter(set, &Command{UseShortOptionHandling: true}, []string{"-vv", "-v"}, false)
+	expect(t, err, nil)
+	expect(t, v, 3)
+	expect(t, set.Lookup("verbose").Value.String(), "3")
+}
+
+func TestEnumFlagHelpOutput(t *testing.T) {
+	fl := &EnumFlag{Name: "format", Allowed: []string{"json", "yaml", "table"}, Value: "table", Usage: "output format"}
+	expect(t, fl.String(), "--format json|yaml|table\toutput format (default: \"table\")")
+
+	fl = &EnumFlag{Name: "format", Allowed: []string{"json", "yaml"}, Usage: "output `FORMAT`"}
+	expect(t, fl.String(), "--format FORMAT\toutput FORMAT")
+}
+
+func TestEnumFlagApply_Validates(t *testing.T) {
+	var v string
+	fl := EnumFlag{Name: "format", Aliases: []string{"f"}, Allowed: []string{"json", "yaml"}, Value: "json", Destination: &v}
+	set := flag.NewFlagSet("test", 0)
+	_ = fl.Apply(set)
+	expect(t, v, "json")
+
+	err := parseIter(set, &Command{}, []string{"--format", "yaml"}, false)
+	expect(t, err, nil)
+	expect(t, v, "yaml")
+
+	err = parseIter(set, &Command{}, []string{"-f", "xml"}, false)
+	expect(t, err, errors.New("invalid value \"xml\" for flag -f (from command line): must be one of json, yaml"))
+}
+
+func TestEnumFlagApply_FromEnv(t *testing.T) {
+	os.Clearenv()
+	_ = os.Setenv("APP_FORMAT", "xml")
+
+	fl := EnumFlag{Name: "format", Allowed: []string{"json", "yaml"}, EnvVars: []string{"APP_FORMAT"}}
+	err := fl.Apply(flag.NewFlagSet("test", 0))
+	expect(t, err, errors.New("could not parse \"xml\" as enum value for flag format: must be one of json, yaml"))
+
+	_ = os.Setenv("APP_FORMAT", "")
+	fl = EnumFlag{Name: "format", Allowed: []string{"json", "yaml"}, EnvVars: []string{"APP_FORMAT"}, Value: "yaml"}
+	set := flag.NewFlagSet("test", 0)
+	expect(t, fl.Apply(set), nil)
+	expect(t, fl.HasBeenSet, false)
+	expect(t, lookupEnum("format", set), "yaml")
+}
+
+func TestEnumFlagApply_InvalidDefault(t *testing.T) {
+	fl := EnumFlag{Name: "format", Allowed: []string{"json", "yaml"}, Value: "xml"}
+	err := fl.Apply(flag.NewFlagSet("test", 0))
+	expect(t, err, errors.New("invalid default value \"xml\" for flag format: must be one of json, yaml"))
+}
+
+func TestEnumSliceFlagHelpOutput(t *testing.T) {
+	fl := &EnumSliceFlag{Name: "level", Allowed: []string{"info", "warn"}, Value: NewStringSlice("info")}
+	expect(t, fl.String(), "--level info|warn\t(default: \"info\")")
+}
+
+func TestEnumSliceFlagApply_Validates(t *testing.T) {
+	fl := EnumSliceFlag{Name: "level", Aliases: []string{"l"}, Allowed: []string{"info", "warn", "error"}}
+	set := flag.NewFlagSet("test", 0)
+	_ = fl.Apply(set)
+
+	err := parseIter(set, &Command{}, []string{"--level", "info", "-l", "warn,error"}, false)
+	expect(t, err, nil)
+	expect(t, lookupEnumSlice("level", set), []string{"info", "warn", "error"})
+
+	err = parseIter(set, &Command{}, []string{"--level", "info,debug"}, false)
+	expect(t, err, errors.New("invalid value \"info,debug\" for flag --level (from command line): must be one of info, warn, error"))
+
+	fl = EnumSliceFlag{Name: "level", Allowed: []string{"info", "warn"}, Value: NewStringSlice("info", "debug")}
+	err = fl.Apply(flag.NewFlagSet("test", 0))
+	expect(t, err, errors.New("invalid default value \"debug\" for flag level: must be one of info, warn"))
+}
+
+func TestStringMapFlagHelpOutput(t *testing.T) {
+	fl := &StringMapFlag{Name: "label", Aliases: []string{"l"}, Value: NewStringMap(map[string]string{"b": "2", "a": "1"})}
+	expect(t, fl.String(), "--label key=value, -l key=value\t(default: \"a=1\", \"b=2\")")
+
+	fl = &StringMapFlag{Name: "label", Separator: ":"}
+	expect(t, fl.String(), "--label key:value\t")
+}
+
+func TestStringMapFlagApply_Separator(t *testing.T) {
+	fl := StringMapFlag{Name: "header", Aliases: []string{"H"}, Separator: ":"}
+	set := flag.NewFlagSet("test", 0)
+	_ = fl.Apply(set)
+
+	err := parseIter(set, &Command{}, []string{"--header", "Accept:text/plain", "-H", "X-A:1,X-B:a:b"}, false)
+	expect(t, err, nil)
+	expect(t, lookupStringMap("header", set), map[string]string{"Accept": "text/plain", "X-A": "1", "X-B": "a:b"})
+
+	err = parseIter(set, &Command{}, []string{"--header", "Accept"}, false)
+	expect(t, err, errors.New("invalid value \"Accept\" for flag --header (from command line): \"Accept\" is not in key:value form"))
+}
+
+func TestStringMapFlagApply_FromEnv(t *testing.T) {
+	os.Clearenv()
+	_ = os.Setenv("APP_LABELS", "A=1,B=2")
+
+	fl := StringMapFlag{Name: "labels", EnvVars: []string{"APP_LABELS"}}
+	set := flag.NewFlagSet("test", 0)
+	err := fl.Apply(set)
+	expect(t, err, nil)
+	expect(t, lookupStringMap("labels", set), map[string]string{"A": "1", "B": "2"})
+
+	_ = os.Setenv("APP_LABELS", "A")
+	fl = StringMapFlag{Name: "labels", EnvVars: []string{"APP_LABELS"}}
+	err = fl.Apply(flag.NewFlagSet("test", 0))
+	expect(t, err, errors.New("could not parse \"A\" as string map value for flag labels: \"A\" is not in key=value form"))
+
+	_ = os.Setenv("APP_LABELS", "tier=web")
+	var labels map[string]string
+	app := newTestApp()
+	app.StringMapVar(&labels, "labels", map[string]string{"env": "dev"}, "", "APP_LABELS")
+	app.Action = func(c *Context) error { return nil }
+	expect(t, app.Run([]string{"app"}), nil)
+	expect(t, labels, map[string]string{"tier": "web"})
+}
+
 var int64FlagTests = []struct {
 	name     string
 	expected string
@@ -549,6 +719,362 @@ func TestUint64FlagWithEnvVarHelpOutput(t *testing.T) {
 	}
 }
 
+var byteSizeFlagTests = []struct {
+	value    uint64
+	expected string
+}{
+	{0, "--cache size\t(default: 0B)"},
+	{512, "--cache size\t(default: 512B)"},
+	{64 << 20, "--cache size\t(default: 64MiB)"},
+	{2e9, "--cache size\t(default: 2GB)"},
+	{1536*1024 + 1, "--cache size\t(default: 1.5MiB)"},
+}
+
+func TestByteSizeFlagHelpOutput(t *testing.T) {
+	for _, test := range byteSizeFlagTests {
+		fl := ByteSizeFlag{Name: "cache", Value: test.value}
+		output := fl.String()
+
+		if output != test.expected {
+			t.Errorf("%s does not match %s", output, test.expected)
+		}
+	}
+}
+
+var byteSizeParseTests = []struct {
+	input    string
+	expected uint64
+	err      bool
+}{
+	{"4096", 4096, false},
+	{"512K", 512000, false},
+	{"512k", 512000, false},
+	{"512KB", 512000, false},
+	{"512Ki", 512 << 10, false},
+	{"10MiB", 10 << 20, false},
+	{"1.5GB", 1500000000, false},
+	{"1.5GiB", 1536 << 20, false},
+	{"2 TB", 2e12, false},
+	{"16EiB", 0, true},
+	{"10XB", 0, true},
+	{"-1", 0, true},
+	{"MB", 0, true},
+}
+
+func TestByteSizeFlagApply(t *testing.T) {
+	for _, test := range byteSizeParseTests {
+		var size uint64
+		fl := ByteSizeFlag{Name: "cache", Aliases: []string{"c"}, Destination: &size}
+		set := flag.NewFlagSet("test", 0)
+		_ = fl.Apply(set)
+
+		err := parseIter(set, &Command{}, []string{"-c", test.input}, false)
+		if test.err {
+			if err == nil {
+				t.Errorf("expected error parsing %q", test.input)
+			}
+			continue
+		}
+		expect(t, err, nil)
+		expect(t, size, test.expected)
+		expect(t, lookupByteSize("cache", set), test.expected)
+	}
+}
+
+func TestByteSizeFlagApply_FromEnv(t *testing.T) {
+	os.Clearenv()
+	_ = os.Setenv("APP_CACHE", "256MiB")
+
+	fl := ByteSizeFlag{Name: "cache", EnvVars: []string{"APP_CACHE"}}
+	set := flag.NewFlagSet("test", 0)
+	err := fl.Apply(set)
+	expect(t, err, nil)
+	expect(t, lookupByteSize("cache", set), uint64(256<<20))
+
+	_ = os.Setenv("APP_CACHE", "lots")
+	fl = ByteSizeFlag{Name: "cache", EnvVars: []string{"APP_CACHE"}}
+	err = fl.Apply(flag.NewFlagSet("test", 0))
+	expect(t, err, errors.New("could not parse \"lots\" as byte size value for flag cache: invalid byte size \"lots\""))
+}
+
+var networkFlagTests = []struct {
+	flag     Flag
+	expected string
+}{
+	{&IPFlag{Name: "bind", Value: net.IPv4(127, 0, 0, 1)}, "--bind ip\t(default: 127.0.0.1)"},
+	{&IPFlag{Name: "bind", Usage: "listen on `ADDR`"}, "--bind ADDR\tlisten on ADDR"},
+	{&IPNetFlag{Name: "allow-cidr", Value: &net.IPNet{IP: net.IPv4(10, 0, 0, 0), Mask: net.CIDRMask(8, 32)}}, "--allow-cidr cidr\t(default: 10.0.0.0/8)"},
+	{&HostPortFlag{Name: "listen", Aliases: []string{"l"}, Value: ":8080"}, "--listen host:port, -l host:port\t(default: :8080)"},
+	{&URLFlag{Name: "endpoint"}, "--endpoint url\t"},
+	{&IPSliceFlag{Name: "dns", Value: NewStringSlice("1.1.1.1", "8.8.8.8")}, "--dns ip\t(default: \"1.1.1.1\", \"8.8.8.8\")"},
+	{&IPNetSliceFlag{Name: "allow-cidr"}, "--allow-cidr cidr\t"},
+	{&HostPortSliceFlag{Name: "peer"}, "--peer host:port\t"},
+	{&URLSliceFlag{Name: "mirror"}, "--mirror url\t"},
+}
+
+func TestNetworkFlagHelpOutput(t *testing.T) {
+	for _, test := range networkFlagTests {
+		output := test.flag.String()
+
+		if output != test.expected {
+			t.Errorf("%q does not match %q", output, test.expected)
+		}
+	}
+}
+
+func TestNetworkFlagApply(t *testing.T) {
+	var ip net.IP
+	var endpoint url.URL
+	set := flag.NewFlagSet("test", 0)
+	_ = (&IPFlag{Name: "bind", Destination: &ip}).Apply(set)
+	_ = (&IPNetFlag{Name: "allow-cidr"}).Apply(set)
+	_ = (&HostPortFlag{Name: "listen"}).Apply(set)
+	_ = (&URLFlag{Name: "endpoint", Schemes: []string{"http", "https"}, Destination: &endpoint}).Apply(set)
+	_ = (&IPSliceFlag{Name: "dns"}).Apply(set)
+	_ = (&IPNetSliceFlag{Name: "deny-cidr"}).Apply(set)
+	_ = (&HostPortSliceFlag{Name: "peer"}).Apply(set)
+	_ = (&URLSliceFlag{Name: "mirror", Schemes: []string{"https"}}).Apply(set)
+
+	err := parseIter(set, &Command{}, []string{
+		"--bind", "::1",
+		"--allow-cidr", "192.168.1.7/24",
+		"--listen", ":8080",
+		"--endpoint", "https://example.com/api",
+		"--dns", "1.1.1.1,8.8.8.8",
+		"--deny-cidr", "10.0.0.0/8",
+		"--peer", "a:1", "--peer", "b:2",
+		"--mirror", "https://a.example.com",
+	}, false)
+	expect(t, err, nil)
+	expect(t, ip, net.ParseIP("::1"))
+	expect(t, lookupIP("bind", set), net.ParseIP("::1"))
+	expect(t, lookupIPNet("allow-cidr", set).String(), "192.168.1.0/24")
+	expect(t, lookupHostPort("listen", set), ":8080")
+	expect(t, endpoint.Host, "example.com")
+	expect(t, lookupURL("endpoint", set).Path, "/api")
+	expect(t, lookupIPSlice("dns", set), []net.IP{net.ParseIP("1.1.1.1"), net.ParseIP("8.8.8.8")})
+	expect(t, len(lookupIPNetSlice("deny-cidr", set)), 1)
+	expect(t, lookupHostPortSlice("peer", set), []string{"a:1", "b:2"})
+	expect(t, lookupURLSlice("mirror", set)[0].Host, "a.example.com")
+}
+
+var networkFlagErrorTests = []struct {
+	args     []string
+	expected string
+}{
+	{[]string{"--bind", "localhost"}, "invalid value \"localhost\" for flag --bind (from command line): invalid IP address \"localhost\""},
+	{[]string{"--allow-cidr", "10.0.0.0"}, "invalid value \"10.0.0.0\" for flag --allow-cidr (from command line): invalid CIDR address \"10.0.0.0\""},
+	{[]string{"--listen", "8080"}, "invalid value \"8080\" for flag --listen (from command line): invalid host:port address \"8080\""},
+	{[]string{"--listen", "host:http"}, "invalid value \"host:http\" for flag --listen (from command line): invalid port \"http\" in address \"host:http\""},
+	{[]string{"--endpoint", "ftp://example.com"}, "invalid value \"ftp://example.com\" for flag --endpoint (from command line): invalid URL \"ftp://example.com\": scheme must be one of http, https"},
+	{[]string{"--dns", "1.1.1.1,one"}, "invalid value \"1.1.1.1,one\" for flag --dns (from command line): invalid IP address \"one\""},
+}
+
+func TestNetworkFlagApply_Validates(t *testing.T) {
+	for _, test := range networkFlagErrorTests {
+		set := flag.NewFlagSet("test", 0)
+		_ = (&IPFlag{Name: "bind"}).Apply(set)
+		_ = (&IPNetFlag{Name: "allow-cidr"}).Apply(set)
+		_ = (&HostPortFlag{Name: "listen"}).Apply(set)
+		_ = (&URLFlag{Name: "endpoint", Schemes: []string{"http", "https"}}).Apply(set)
+		_ = (&IPSliceFlag{Name: "dns"}).Apply(set)
+
+		err := parseIter(set, &Command{}, test.args, false)
+		expect(t, err, errors.New(test.expected))
+	}
+}
+
+func TestNetworkFlagApply_FromEnv(t *testing.T) {
+	os.Clearenv()
+	_ = os.Setenv("APP_BIND", "10.1.2.3")
+	_ = os.Setenv("APP_PEERS", "a:1,b")
+
+	set := flag.NewFlagSet("test", 0)
+	err := (&IPFlag{Name: "bind", EnvVars: []string{"APP_BIND"}}).Apply(set)
+	expect(t, err, nil)
+	expect(t, lookupIP("bind", set), net.ParseIP("10.1.2.3"))
+
+	err = (&HostPortSliceFlag{Name: "peer", EnvVars: []string{"APP_PEERS"}}).Apply(set)
+	expect(t, err, errors.New("could not parse \"a:1,b\" as host:port value for flag peer: invalid host:port address \"b\""))
+}
+
+var splitResponseFileTests = []struct {
+	content  string
+	expected []string
+}{
+	{"--name gopher\n--verbose\n", []string{"--name", "gopher", "--verbose"}},
+	{"# a comment\n--name 'go pher' # trailing\n", []string{"--name", "go pher"}},
+	{`--json "{\"a\": 1}" it\'s`, []string{"--json", `{"a": 1}`, "it's"}},
+	{"--list a,\\\nb", []string{"--list", "a,b"}},
+	{`''`, []string{""}},
+}
+
+func TestSplitResponseFile(t *testing.T) {
+	for _, test := range splitResponseFileTests {
+		args, err := splitResponseFile(test.content)
+		expect(t, err, nil)
+		expect(t, args, test.expected)
+	}
+
+	_, err := splitResponseFile(`--name "gopher`)
+	expect(t, err, errors.New("unterminated double quote"))
+}
+
+func TestParseIter_ValueFromFile(t *testing.T) {
+	dir, err := ioutil.TempDir("", "cli")
+	expect(t, err, nil)
+	defer os.RemoveAll(dir)
+
+	payload := dir + "/payload.json"
+	_ = ioutil.WriteFile(payload, []byte("{\"a\": 1}\n"), 0644)
+	_ = ioutil.WriteFile(dir+"/servers.txt", []byte("1.1.1.1,8.8.8.8\n"), 0644)
+	_ = ioutil.WriteFile(dir+"/nets.txt", []byte("10.0.0.0/8\n"), 0644)
+	_ = ioutil.WriteFile(dir+"/peers.txt", []byte("db:5432\n"), 0644)
+	_ = ioutil.WriteFile(dir+"/mirrors.txt", []byte("https://example.com\n"), 0644)
+
+	defer func() { stdin = os.Stdin }()
+	stdin = strings.NewReader("-----BEGIN CERTIFICATE-----\n")
+
+	set := flag.NewFlagSet("test", 0)
+	cmd := &Command{Flags: []Flag{
+		&StringFlag{Name: "data", Aliases: []string{"d"}, ValueFromFile: true},
+		&StringFlag{Name: "cert", ValueFromFile: true},
+		&StringFlag{Name: "user"},
+		&IPSliceFlag{Name: "dns", ValueFromFile: true},
+		&IPNetSliceFlag{Name: "allow", ValueFromFile: true},
+		&HostPortSliceFlag{Name: "peer", ValueFromFile: true},
+		&URLSliceFlag{Name: "mirror", ValueFromFile: true},
+	}}
+	for _, f := range cmd.Flags {
+		_ = f.Apply(set)
+	}
+
+	err = parseIter(set, cmd, []string{"--data", "@" + payload, "--cert=@-", "--user", "@gopher"}, false)
+	expect(t, err, nil)
+	expect(t, lookupString("data", set), `{"a": 1}`)
+	expect(t, lookupString("cert", set), "-----BEGIN CERTIFICATE-----")
+	expect(t, lookupString("user", set), "@gopher")
+
+	err = parseIter(set, cmd, []string{
+		"--dns", "@" + dir + "/servers.txt",
+		"--allow", "@" + dir + "/nets.txt",
+		"--peer", "@" + dir + "/peers.txt",
+		"--mirror=@" + dir + "/mirrors.txt",
+	}, false)
+	expect(t, err, nil)
+	expect(t, lookupIPSlice("dns", set), []net.IP{net.ParseIP("1.1.1.1"), net.ParseIP("8.8.8.8")})
+	expect(t, lookupIPNetSlice("allow", set)[0].String(), "10.0.0.0/8")
+	expect(t, lookupHostPortSlice("peer", set), []string{"db:5432"})
+	expect(t, lookupURLSlice("mirror", set)[0].String(), "https://example.com")
+
+	err = parseIter(set, cmd, []string{"-d", "@" + dir + "/missing.json"}, false)
+	if err == nil || !strings.HasPrefix(err.Error(), "could not read value for flag -d: ") {
+		t.Errorf("expected an error reading the value of -d; got: %v", err)
+	}
+}
+
+type bindTestConfig struct {
+	Name    string        `cli:"name,n" env:"APP_NAME" usage:"your name" required:"true"`
+	Verbose bool          `usage:"be verbose"`
+	Timeout time.Duration `default:"5s"`
+	Tags    []string      `default:"a,b"`
+	Ports   []int
+	Labels  map[string]string
+	MaxConn uint64 `cli:"max-conn" hidden:"true"`
+	DB      struct {
+		Host string `default:"localhost"`
+		Port int    `cli:"port,p"`
+	}
+	Skipped  string `cli:"-"`
+	internal string
+}
+
+func TestFlagsFromStruct(t *testing.T) {
+	var cfg bindTestConfig
+	flags, err := FlagsFromStruct(&cfg)
+	expect(t, err, nil)
+
+	var names []string
+	for _, f := range flags {
+		names = append(names, f.Names()...)
+	}
+	expect(t, names, []string{"name", "n", "verbose", "timeout", "tags", "ports", "labels", "max-conn", "db-host", "db-port", "p"})
+
+	name := flags[0].(*StringFlag)
+	expect(t, name.Usage, "your name")
+	expect(t, name.EnvVars, []string{"APP_NAME"})
+	expect(t, name.Required, true)
+	expect(t, flags[6].(*Uint64Flag).Hidden, true)
+	expect(t, cfg.Timeout, 5*time.Second)
+	expect(t, cfg.DB.Host, "localhost")
+
+	_, err = FlagsFromStruct(cfg)
+	expect(t, err, errors.New("cli: FlagsFromStruct expects a pointer to a struct, got cli.bindTestConfig"))
+
+	_, err = FlagsFromStruct(&struct{ C chan int }{})
+	expect(t, err, errors.New("cli: field C: unsupported field type chan int"))
+
+	_, err = FlagsFromStruct(&struct{ Since time.Time }{})
+	expect(t, err, errors.New("cli: field Since: unsupported field type time.Time"))
+
+	_, err = FlagsFromStruct(&struct {
+		DB struct{ Host string } `cli:"database"`
+	}{})
+	expect(t, err, errors.New("cli: field DB: unsupported field type struct { Host string }"))
+
+	_, err = FlagsFromStruct(&struct {
+		N int `default:"many"`
+	}{})
+	expect(t, err, errors.New("cli: invalid default \"many\" for field N: parse error"))
+}
+
+var kebabCaseTests = []struct {
+	name     string
+	expected string
+}{
+	{"Name", "name"},
+	{"MaxConns", "max-conns"},
+	{"HTTPAddr", "http-addr"},
+	{"DBHost", "db-host"},
+	{"UserID", "user-id"},
+}
+
+func TestKebabCase(t *testing.T) {
+	for _, test := range kebabCaseTests {
+		expect(t, kebabCase(test.name), test.expected)
+	}
+}
+
+var validatorTests = []struct {
+	validator ValidatorFunc
+	value     interface{}
+	expected  error
+}{
+	{Min(1), 1, nil},
+	{Min(1), 0, errors.New("must be at least 1")},
+	{Max(2.5), 2.5, nil},
+	{Max(2.5), 3.0, errors.New("must be at most 2.5")},
+	{Min(time.Second), 500 * time.Millisecond, errors.New("must be at least 1s")},
+	{Max(uint64(10)), uint64(11), errors.New("must be at most 10")},
+	{Min(1), "1", errors.New("unsupported type string for a numeric constraint")},
+	{MinLen(3), "héé", nil},
+	{MinLen(3), "ab", errors.New("must be at least 3 characters long")},
+	{MaxLen(2), "abc", errors.New("must be at most 2 characters long")},
+	{Pattern("^[a-z]+$"), "abc", nil},
+	{Pattern("^[a-z]+$"), "ab1", errors.New("must match ^[a-z]+$")},
+	{MinItems(1), []string{}, errors.New("must have at least 1 items")},
+	{MaxItems(1), []int{1, 2}, errors.New("must have at most 1 items")},
+	{MaxItems(1), map[string]string{"a": "1"}, nil},
+	{Validators(Min(1), Max(3)), 2, nil},
+	{Validators(Min(1), Max(3)), 4, errors.New("must be at most 3")},
+}
+
+func TestValidators(t *testing.T) {
+	for _, test := range validatorTests {
+		expect(t, test.validator(test.value), test.expected)
+	}
+}
+
 var durationFlagTests = []struct {
 	name     string
 	expected string
@@ -914,6 +1440,27 @@ func TestParseMultiStringSlice(t *testing.T) {
 	}).Run([]string{"run", "-s", "10", "-s", "20"})
 }
 
+func TestParseMultiStringMap(t *testing.T) {
+	var labels map[string]string
+	app := &App{
+		Action: func(ctx *Context) error {
+			expected := map[string]string{"env": "prod", "tier": "web"}
+			if !reflect.DeepEqual(ctx.StringMap("label"), expected) {
+				t.Errorf("main name not set: %v != %v", expected, ctx.StringMap("label"))
+			}
+			if !reflect.DeepEqual(ctx.StringMap("l"), expected) {
+				t.Errorf("short name not set: %v != %v", expected, ctx.StringMap("l"))
+			}
+			return nil
+		},
+	}
+	app.StringMapVarP(&labels, "label", "l", map[string]string{"env": "dev"}, "", "")
+
+	err := app.Run([]string{"run", "-l", "env=prod", "-l", "tier=web"})
+	expect(t, err, nil)
+	expect(t, labels, map[string]string{"env": "prod", "tier": "web"})
+}
+
 func TestParseMultiStringSliceWithDefaults(t *testing.T) {
 	_ = (&App{
 		Flags: []Flag{
@@ -1662,6 +2209,22 @@ func TestStringSlice_Serialized_Set(t *testing.T) {
 	}
 }
 
+func TestStringMap_Serialized_Set(t *testing.T) {
+	m0 := NewStringMap(map[string]string{"a": "1", "b": "2"})
+	ser0 := m0.Serialize()
+
+	if len(ser0) < len(slPfx) {
+		t.Fatalf("serialized shorter than expected: %q", ser0)
+	}
+
+	m1 := NewStringMap(map[string]string{"c": "3"})
+	_ = m1.Set(ser0)
+
+	if m0.String() != m1.String() {
+		t.Fatalf("pre and post serialization do not match: %v != %v", m0, m1)
+	}
+}
+
 func TestIntSlice_Serialized_Set(t *testing.T) {
 	sl0 := NewIntSlice(1, 2)
 	ser0 := sl0.Serialize()
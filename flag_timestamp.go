@@ -84,17 +84,24 @@ func (t *Timestamp) Get() interface{} {
 
 // TimestampFlag is a flag with type time
 type TimestampFlag struct {
-	Name        string
-	Aliases     []string
-	Usage       string
-	EnvVars     []string
-	FilePath    string
-	Required    bool
-	Hidden      bool
-	Layout      string
-	Value       *Timestamp
-	DefaultText string
-	HasBeenSet  bool
+	Name          string
+	Aliases       []string
+	Usage         string
+	EnvVars       []string
+	FilePath      string
+	Required      bool
+	Hidden        bool
+	Layout        string
+	Value         *Timestamp
+	DefaultText   string
+	HasBeenSet    bool
+	Validator     ValidatorFunc
+	Deprecated    string
+	ReplacedBy    string
+	Action        FlagActionFunc
+	NoAutoEnv     bool
+	ValueFromFile bool
+	Persistent    bool
 }
 
 // IsSet returns whether or not the flag has been set through env or file
@@ -215,12 +222,22 @@ func (a *App) TimestampP(name, alias string, value time.Time, usage, env string)
 
 // Timestamp gets the timestamp from a flag name
 func (c *Context) Timestamp(name string) *time.Time {
-	if fs := lookupFlagSet(name, c); fs != nil {
+	if fs := c.strictFlagSet(name); fs != nil {
 		return lookupTimestamp(name, fs)
 	}
 	return nil
 }
 
+// GetTimestamp gets the timestamp from a flag name, returns an error if the
+// flag is not defined
+func (c *Context) GetTimestamp(name string) (*time.Time, error) {
+	fs, err := c.definedFlagSet(name)
+	if err != nil {
+		return nil, err
+	}
+	return lookupTimestamp(name, fs), nil
+}
+
 // Fetches the timestamp value from the local timestampWrap
 func lookupTimestamp(name string, set *flag.FlagSet) *time.Time {
 	f := set.Lookup(name)
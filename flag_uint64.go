@@ -22,17 +22,24 @@ import (
 
 // Uint64Flag is a flag with type uint64
 type Uint64Flag struct {
-	Name        string
-	Aliases     []string
-	Usage       string
-	EnvVars     []string
-	FilePath    string
-	Required    bool
-	Hidden      bool
-	Value       uint64
-	DefaultText string
-	Destination *uint64
-	HasBeenSet  bool
+	Name          string
+	Aliases       []string
+	Usage         string
+	EnvVars       []string
+	FilePath      string
+	Required      bool
+	Hidden        bool
+	Value         uint64
+	DefaultText   string
+	Destination   *uint64
+	HasBeenSet    bool
+	Validator     ValidatorFunc
+	Deprecated    string
+	ReplacedBy    string
+	Action        FlagActionFunc
+	NoAutoEnv     bool
+	ValueFromFile bool
+	Persistent    bool
 }
 
 // IsSet returns whether or not the flag has been set through env or file
@@ -156,12 +163,22 @@ func (a *App) Uint64P(name, alias string, value uint64, usage, env string) *uint
 // Uint64 looks up the value of a local Uint64Flag, returns
 // 0 if not found
 func (c *Context) Uint64(name string) uint64 {
-	if fs := lookupFlagSet(name, c); fs != nil {
+	if fs := c.strictFlagSet(name); fs != nil {
 		return lookupUint64(name, fs)
 	}
 	return 0
 }
 
+// GetUint64 looks up the value of an Uint64Flag, returns an error if the
+// flag is not defined
+func (c *Context) GetUint64(name string) (uint64, error) {
+	fs, err := c.definedFlagSet(name)
+	if err != nil {
+		return 0, err
+	}
+	return lookupUint64(name, fs), nil
+}
+
 func lookupUint64(name string, set *flag.FlagSet) uint64 {
 	f := set.Lookup(name)
 	if f != nil {
@@ -0,0 +1,323 @@
+// Copyright 2020 The vine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cli
+
+import (
+	"flag"
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// urlValue wraps a url.URL, optionally restricted to a set of schemes, to
+// satisfy flag.Value
+type urlValue struct {
+	schemes []string
+	value   *url.URL
+}
+
+func newURLValue(schemes []string, value *url.URL, p *url.URL) *urlValue {
+	if p == nil {
+		p = new(url.URL)
+	}
+	if value != nil {
+		*p = *value
+	}
+	return &urlValue{schemes: schemes, value: p}
+}
+
+// Set parses the value as a URL with one of the allowed schemes
+func (u *urlValue) Set(value string) error {
+	parsed, err := parseURL(u.schemes, value)
+	if err != nil {
+		return err
+	}
+	*u.value = *parsed
+	return nil
+}
+
+// String returns a readable representation of this value
+func (u *urlValue) String() string {
+	if u.value == nil {
+		return ""
+	}
+	return u.value.String()
+}
+
+// Get returns the URL
+func (u *urlValue) Get() interface{} {
+	return u.value
+}
+
+// parseURL parses value as a URL, when schemes is not empty the URL must be
+// absolute and use one of them
+func parseURL(schemes []string, value string) (*url.URL, error) {
+	u, err := url.Parse(strings.TrimSpace(value))
+	if err != nil {
+		return nil, fmt.Errorf("invalid URL %q", value)
+	}
+	if len(schemes) > 0 {
+		for _, scheme := range schemes {
+			if strings.EqualFold(u.Scheme, scheme) {
+				return u, nil
+			}
+		}
+		return nil, fmt.Errorf("invalid URL %q: scheme must be one of %s", value, strings.Join(schemes, ", "))
+	}
+	return u, nil
+}
+
+// URLFlag is a flag with type *url.URL, restricted to the listed Schemes
+// unless empty
+type URLFlag struct {
+	Name          string
+	Aliases       []string
+	Usage         string
+	EnvVars       []string
+	FilePath      string
+	Required      bool
+	Hidden        bool
+	Schemes       []string
+	Value         *url.URL
+	DefaultText   string
+	Destination   *url.URL
+	HasBeenSet    bool
+	Validator     ValidatorFunc
+	Deprecated    string
+	ReplacedBy    string
+	Action        FlagActionFunc
+	NoAutoEnv     bool
+	ValueFromFile bool
+	Persistent    bool
+}
+
+// IsSet returns whether or not the flag has been set through env or file
+func (f *URLFlag) IsSet() bool {
+	return f.HasBeenSet
+}
+
+// String returns a readable representation of this value
+// (for usage defaults)
+func (f *URLFlag) String() string {
+	return FlagStringer(f)
+}
+
+// Names returns the names of the flag
+func (f *URLFlag) Names() []string {
+	return flagNames(f.Name, f.Aliases)
+}
+
+// IsRequired returns whether or not the flag is required
+func (f *URLFlag) IsRequired() bool {
+	return f.Required
+}
+
+// TakesValue returns true of the flag takes a value, otherwise false
+func (f *URLFlag) TakesValue() bool {
+	return true
+}
+
+// GetUsage returns the usage string for the flag
+func (f *URLFlag) GetUsage() string {
+	return f.Usage
+}
+
+// GetValue returns the flags value as string representation and an empty
+// string if the flag takes no value at all.
+func (f *URLFlag) GetValue() string {
+	if f.Value != nil {
+		return f.Value.String()
+	}
+	return ""
+}
+
+// Apply populates the flag given the flag set and environment
+func (f *URLFlag) Apply(set *flag.FlagSet) error {
+	if val, ok := flagFromEnvOrFile(f.EnvVars, f.FilePath); ok {
+		if val != "" {
+			u, err := parseURL(f.Schemes, val)
+			if err != nil {
+				return fmt.Errorf("could not parse %q as URL value for flag %s: %s", val, f.Name, err)
+			}
+
+			f.Value = u
+			f.HasBeenSet = true
+		}
+	}
+
+	value := newURLValue(f.Schemes, f.Value, f.Destination)
+	for _, name := range f.Names() {
+		set.Var(value, name, f.Usage)
+	}
+
+	return nil
+}
+
+// URL looks up the value of a local URLFlag, returns
+// nil if not found
+func (c *Context) URL(name string) *url.URL {
+	if fs := c.strictFlagSet(name); fs != nil {
+		return lookupURL(name, fs)
+	}
+	return nil
+}
+
+// GetURL looks up the value of an URLFlag, returns an error if the
+// flag is not defined
+func (c *Context) GetURL(name string) (*url.URL, error) {
+	fs, err := c.definedFlagSet(name)
+	if err != nil {
+		return nil, err
+	}
+	return lookupURL(name, fs), nil
+}
+
+func lookupURL(name string, set *flag.FlagSet) *url.URL {
+	f := set.Lookup(name)
+	if f != nil {
+		if value, ok := f.Value.(*urlValue); ok && *value.value != (url.URL{}) {
+			u := *value.value
+			return &u
+		}
+	}
+	return nil
+}
+
+// URLSliceFlag is a flag with type *StringSlice whose items are URLs,
+// restricted to the listed Schemes unless empty
+type URLSliceFlag struct {
+	Name          string
+	Aliases       []string
+	Usage         string
+	EnvVars       []string
+	FilePath      string
+	Required      bool
+	Hidden        bool
+	Schemes       []string
+	Value         *StringSlice
+	DefaultText   string
+	HasBeenSet    bool
+	Validator     ValidatorFunc
+	Deprecated    string
+	ReplacedBy    string
+	Action        FlagActionFunc
+	NoAutoEnv     bool
+	ValueFromFile bool
+	Persistent    bool
+}
+
+// IsSet returns whether or not the flag has been set through env or file
+func (f *URLSliceFlag) IsSet() bool {
+	return f.HasBeenSet
+}
+
+// String returns a readable representation of this value
+// (for usage defaults)
+func (f *URLSliceFlag) String() string {
+	return FlagStringer(f)
+}
+
+// Names returns the names of the flag
+func (f *URLSliceFlag) Names() []string {
+	return flagNames(f.Name, f.Aliases)
+}
+
+// IsRequired returns whether or not the flag is required
+func (f *URLSliceFlag) IsRequired() bool {
+	return f.Required
+}
+
+// TakesValue returns true of the flag takes a value, otherwise false
+func (f *URLSliceFlag) TakesValue() bool {
+	return true
+}
+
+// GetUsage returns the usage string for the flag
+func (f *URLSliceFlag) GetUsage() string {
+	return f.Usage
+}
+
+// GetValue returns the flags value as string representation and an empty
+// string if the flag takes no value at all.
+func (f *URLSliceFlag) GetValue() string {
+	if f.Value != nil {
+		return f.Value.String()
+	}
+	return ""
+}
+
+func (f *URLSliceFlag) validate(item string) error {
+	_, err := parseURL(f.Schemes, item)
+	return err
+}
+
+// Apply populates the flag given the flag set and environment
+func (f *URLSliceFlag) Apply(set *flag.FlagSet) error {
+	if val, ok := flagFromEnvOrFile(f.EnvVars, f.FilePath); ok {
+		value := &validatedSlice{validate: f.validate, slice: &StringSlice{}}
+
+		for _, s := range strings.Split(val, ",") {
+			if err := value.Set(strings.TrimSpace(s)); err != nil {
+				return fmt.Errorf("could not parse %q as URL value for flag %s: %s", val, f.Name, err)
+			}
+		}
+
+		f.Value = value.slice
+		f.HasBeenSet = true
+	}
+
+	if f.Value == nil {
+		f.Value = &StringSlice{}
+	}
+	value := &validatedSlice{validate: f.validate, slice: f.Value}
+	for _, name := range f.Names() {
+		set.Var(value, name, f.Usage)
+	}
+
+	return nil
+}
+
+// URLSlice looks up the value of a local URLSliceFlag, returns
+// nil if not found
+func (c *Context) URLSlice(name string) []*url.URL {
+	if fs := c.strictFlagSet(name); fs != nil {
+		return lookupURLSlice(name, fs)
+	}
+	return nil
+}
+
+// GetURLSlice looks up the value of an URLSliceFlag, returns an error if the
+// flag is not defined
+func (c *Context) GetURLSlice(name string) ([]*url.URL, error) {
+	fs, err := c.definedFlagSet(name)
+	if err != nil {
+		return nil, err
+	}
+	return lookupURLSlice(name, fs), nil
+}
+
+func lookupURLSlice(name string, set *flag.FlagSet) []*url.URL {
+	items := lookupValidatedSlice(name, set)
+	if items == nil {
+		return nil
+	}
+	urls := make([]*url.URL, 0, len(items))
+	for _, item := range items {
+		if u, err := url.Parse(item); err == nil {
+			urls = append(urls, u)
+		}
+	}
+	return urls
+}
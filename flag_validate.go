@@ -0,0 +1,276 @@
+// Copyright 2020 The vine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cli
+
+import (
+	"flag"
+	"fmt"
+	"reflect"
+	"regexp"
+	"time"
+	"unicode/utf8"
+)
+
+// flagValidationError reports a flag value rejected by its validator
+type flagValidationError struct {
+	name   string
+	value  string
+	source string
+	err    error
+}
+
+func (e *flagValidationError) Error() string {
+	return fmt.Sprintf("invalid value %q for flag %s (from %s): %s", e.value, e.name, e.source, e.err)
+}
+
+// Unwrap returns the error of the validator
+func (e *flagValidationError) Unwrap() error {
+	return e.err
+}
+
+// ValidateFlag runs the Validator of the flag, if any, on its value in the
+// context. The error names the flag and the source the value came from.
+func ValidateFlag(c *Context, f Flag, source string) error {
+	validator := flagValidator(f)
+	if validator == nil {
+		return nil
+	}
+
+	name := f.Names()[0]
+	fl := lookupContextFlag(name, c)
+	if fl == nil {
+		return nil
+	}
+
+	if err := validator(flagGetValue(fl.Value)); err != nil {
+		return &flagValidationError{
+			name:   prefixFor(name) + name,
+			value:  fl.Value.String(),
+			source: source,
+			err:    err,
+		}
+	}
+	return nil
+}
+
+// checkFlagValidators validates the flags set on the command line, through
+// the environment or from files
+func checkFlagValidators(flags []Flag, context *Context) error {
+	for _, f := range flags {
+		if flagValidator(f) == nil {
+			continue
+		}
+		source := flagSource(f, context)
+		if source.Kind == DefaultSource {
+			continue
+		}
+		if err := ValidateFlag(context, f, source.String()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// lookupContextFlag returns the parsed flag named name in the context or in
+// one of its parents
+func lookupContextFlag(name string, c *Context) *flag.Flag {
+	fs := lookupFlagSet(name, c)
+	if fs == nil {
+		return nil
+	}
+	return fs.Lookup(name)
+}
+
+func isFlagVisited(set *flag.FlagSet, name string) bool {
+	visited := false
+	set.Visit(func(f *flag.Flag) {
+		if f.Name == name {
+			visited = true
+		}
+	})
+	return visited
+}
+
+func flagValidator(f Flag) ValidatorFunc {
+	fv := flagValue(f).FieldByName("Validator")
+	if !fv.IsValid() || fv.IsNil() {
+		return nil
+	}
+	validator, _ := fv.Interface().(ValidatorFunc)
+	return validator
+}
+
+// flagGetValue returns the parsed value of a flag
+func flagGetValue(v flag.Value) interface{} {
+	switch v := v.(type) {
+	case *StringSlice:
+		return v.Value()
+	case *IntSlice:
+		return v.Value()
+	case *Int64Slice:
+		return v.Value()
+	case *Float64Slice:
+		return v.Value()
+	case *StringMap:
+		return v.Value()
+	case flag.Getter:
+		return v.Get()
+	}
+	return v
+}
+
+// Validators returns a ValidatorFunc running each of the validators in turn,
+// stopping at the first error
+func Validators(validators ...ValidatorFunc) ValidatorFunc {
+	return func(value interface{}) error {
+		for _, validator := range validators {
+			if err := validator(value); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+}
+
+// Min returns a ValidatorFunc checking a numeric value, including
+// time.Duration, is at least min
+func Min(min interface{}) ValidatorFunc {
+	return func(value interface{}) error {
+		v, m, err := numericPair(value, min)
+		if err != nil {
+			return err
+		}
+		if v < m {
+			return fmt.Errorf("must be at least %v", min)
+		}
+		return nil
+	}
+}
+
+// Max returns a ValidatorFunc checking a numeric value, including
+// time.Duration, is at most max
+func Max(max interface{}) ValidatorFunc {
+	return func(value interface{}) error {
+		v, m, err := numericPair(value, max)
+		if err != nil {
+			return err
+		}
+		if v > m {
+			return fmt.Errorf("must be at most %v", max)
+		}
+		return nil
+	}
+}
+
+func numericPair(value, limit interface{}) (float64, float64, error) {
+	v, ok := toFloat64(value)
+	if !ok {
+		return 0, 0, fmt.Errorf("unsupported type %T for a numeric constraint", value)
+	}
+	l, ok := toFloat64(limit)
+	if !ok {
+		return 0, 0, fmt.Errorf("unsupported type %T for a numeric limit", limit)
+	}
+	return v, l, nil
+}
+
+func toFloat64(value interface{}) (float64, bool) {
+	if d, ok := value.(time.Duration); ok {
+		return float64(d), true
+	}
+	rv := reflect.ValueOf(value)
+	switch rv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return float64(rv.Int()), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return float64(rv.Uint()), true
+	case reflect.Float32, reflect.Float64:
+		return rv.Float(), true
+	}
+	return 0, false
+}
+
+// MinLen returns a ValidatorFunc checking a string value has at least n
+// characters
+func MinLen(n int) ValidatorFunc {
+	return func(value interface{}) error {
+		if utf8.RuneCountInString(fmt.Sprint(value)) < n {
+			return fmt.Errorf("must be at least %d characters long", n)
+		}
+		return nil
+	}
+}
+
+// MaxLen returns a ValidatorFunc checking a string value has at most n
+// characters
+func MaxLen(n int) ValidatorFunc {
+	return func(value interface{}) error {
+		if utf8.RuneCountInString(fmt.Sprint(value)) > n {
+			return fmt.Errorf("must be at most %d characters long", n)
+		}
+		return nil
+	}
+}
+
+// Pattern returns a ValidatorFunc checking a string value matches the
+// regular expression expr, it panics if expr does not compile
+func Pattern(expr string) ValidatorFunc {
+	re := regexp.MustCompile(expr)
+	return func(value interface{}) error {
+		if !re.MatchString(fmt.Sprint(value)) {
+			return fmt.Errorf("must match %s", expr)
+		}
+		return nil
+	}
+}
+
+// MinItems returns a ValidatorFunc checking a slice or map value has at
+// least n items
+func MinItems(n int) ValidatorFunc {
+	return func(value interface{}) error {
+		l, err := itemCount(value)
+		if err != nil {
+			return err
+		}
+		if l < n {
+			return fmt.Errorf("must have at least %d items", n)
+		}
+		return nil
+	}
+}
+
+// MaxItems returns a ValidatorFunc checking a slice or map value has at
+// most n items
+func MaxItems(n int) ValidatorFunc {
+	return func(value interface{}) error {
+		l, err := itemCount(value)
+		if err != nil {
+			return err
+		}
+		if l > n {
+			return fmt.Errorf("must have at most %d items", n)
+		}
+		return nil
+	}
+}
+
+func itemCount(value interface{}) (int, error) {
+	rv := reflect.ValueOf(value)
+	switch rv.Kind() {
+	case reflect.Slice, reflect.Map, reflect.Array:
+		return rv.Len(), nil
+	}
+	return 0, fmt.Errorf("unsupported type %T for an item count constraint", value)
+}
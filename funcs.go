@@ -28,6 +28,14 @@ type AfterFunc func(*Context) error
 // ActionFunc is the action to execute when no subcommands are specified
 type ActionFunc func(*Context) error
 
+// Middleware wraps the action of the command that runs, i.e. to log, time or
+// authorize it. It calls next to run the action.
+type Middleware func(next ActionFunc) ActionFunc
+
+// PanicHandlerFunc is executed with the panic recovered from Before, Action or
+// After when RecoverPanics is set, i.e. to report the crash
+type PanicHandlerFunc func(context *Context, err *PanicError)
+
 // CommandNotFoundFunc is executed if the proper command cannot be found
 type CommandNotFoundFunc func(*Context, string)
 
@@ -41,6 +49,15 @@ type OnUsageErrorFunc func(context *Context, err error, isSubcommand bool) error
 // returned by Actions and Before/After functions.
 type ExitErrHandlerFunc func(context *Context, err error)
 
+// ValidatorFunc checks the value of a flag once it is set, it is given the
+// parsed value, i.e. an int for an IntFlag or a []string for a StringSliceFlag
+type ValidatorFunc func(value interface{}) error
+
+// FlagActionFunc is executed once per run for a flag which has been set,
+// before the Before and Action functions. It is given the parsed value of
+// the flag like a ValidatorFunc.
+type FlagActionFunc func(context *Context, value interface{}) error
+
 // FlagStringFunc is used by the help generation to display a flag, which is
 // expected to be a single line.
 type FlagStringFunc func(Flag) string
@@ -49,6 +66,10 @@ type FlagStringFunc func(Flag) string
 // text for a flag's full name.
 type FlagNamePrefixFunc func(fullName []string, placeholder string) string
 
+// EnvVarNameFunc is used to derive the name of an environment variable from
+// a prefix and the name of a flag or a command.
+type EnvVarNameFunc func(prefix, name string) string
+
 // FlagEnvHintFunc is used by the default FlagStringFunc to annotate flag help
 // with the environment variable details.
 type FlagEnvHintFunc func(envVars []string, str string) string
@@ -15,6 +15,7 @@
 package cli
 
 import (
+	"errors"
 	"fmt"
 	"io"
 	"os"
@@ -32,14 +33,14 @@ var helpCommand = &Command{
 	Action: func(c *Context) error {
 		args := c.Args()
 		if args.Present() {
-			_ = ShowCommandHelp(c, args.First())
-			os.Exit(0)
-			return nil
+			if err := ShowCommandHelp(c, args.First()); err != nil {
+				return err
+			}
+			return helpShown(c)
 		}
 
 		_ = ShowAppHelp(c)
-		os.Exit(0)
-		return nil
+		return helpShown(c)
 	},
 }
 
@@ -51,17 +52,30 @@ var helpSubcommand = &Command{
 	Action: func(c *Context) error {
 		args := c.Args()
 		if args.Present() {
-			_ = ShowCommandHelp(c, args.First())
-			os.Exit(0)
-			return nil
+			if err := ShowCommandHelp(c, args.First()); err != nil {
+				return err
+			}
+			return helpShown(c)
 		}
 
 		_ = ShowSubcommandHelp(c)
-		os.Exit(0)
-		return nil
+		return helpShown(c)
 	},
 }
 
+// ErrHelpShown is returned by Run when help or the version was shown
+// instead of running an action, check for it with errors.Is
+var ErrHelpShown = errors.New("help shown")
+
+// helpShown ends the handling of help or of the version, exiting through
+// OsExiter when the App asks for it
+func helpShown(c *Context) error {
+	if c.App != nil && c.App.ExitOnHelp {
+		OsExiter(0)
+	}
+	return ErrHelpShown
+}
+
 // Prints help for the App or Command
 type helpPrinter func(w io.Writer, templ string, data interface{})
 
@@ -88,7 +102,7 @@ var VersionPrinter = printVersion
 // ShowAppHelpAndExit - Prints the list of subcommands for the app and exits with exit code.
 func ShowAppHelpAndExit(c *Context, exitCode int) {
 	_ = ShowAppHelp(c)
-	os.Exit(exitCode)
+	OsExiter(exitCode)
 }
 
 // ShowAppHelp is an action that displays the help.
@@ -120,7 +134,7 @@ func DefaultAppComplete(c *Context) {
 
 func printCommandSuggestions(commands []*Command, writer io.Writer) {
 	for _, command := range commands {
-		if command.Hidden {
+		if command.Hidden || command.isDeprecated() {
 			continue
 		}
 		if os.Getenv("_CLI_ZSH_AUTOCOMPLETE_HACK") == "1" {
@@ -159,7 +173,14 @@ func printFlagSuggestions(lastArg string, flags []Flag, writer io.Writer) {
 		if bflag, ok := flag.(*BoolFlag); ok && bflag.Hidden {
 			continue
 		}
-		for _, name := range flag.Names() {
+		if isFlagDeprecated(flag) {
+			continue
+		}
+		names := flag.Names()
+		if nf, ok := flag.(negatableFlag); ok {
+			names = append(names, nf.negatedNames()...)
+		}
+		for _, name := range names {
 			name = strings.TrimSpace(name)
 			// this will get total count utf8 letters in flag name
 			count := utf8.RuneCountInString(name)
@@ -180,11 +201,46 @@ func printFlagSuggestions(lastArg string, flags []Flag, writer io.Writer) {
 	}
 }
 
+// printFlagValueSuggestions prints the allowed values of a ChoiceFlag when
+// lastArg is that flag, i.e. "--format" or "--format=". It returns false if
+// lastArg does not name such a flag.
+func printFlagValueSuggestions(lastArg string, flags []Flag, writer io.Writer) bool {
+	prefix := ""
+	if i := strings.Index(lastArg, "="); i >= 0 {
+		prefix, lastArg = lastArg[:i+1], lastArg[:i]
+	}
+	name := strings.TrimLeft(lastArg, "-")
+
+	for _, flag := range flags {
+		cf, ok := flag.(ChoiceFlag)
+		if !ok {
+			continue
+		}
+		for _, n := range flag.Names() {
+			if strings.TrimSpace(n) != name {
+				continue
+			}
+			for _, value := range cf.GetAllowed() {
+				_, _ = fmt.Fprintln(writer, prefix+value)
+			}
+			return true
+		}
+	}
+	return false
+}
+
 func DefaultCompleteWithFlags(cmd *Command) func(c *Context) {
 	return func(c *Context) {
 		if len(os.Args) > 2 {
 			lastArg := os.Args[len(os.Args)-2]
 			if strings.HasPrefix(lastArg, "-") {
+				flags := c.App.Flags
+				if cmd != nil {
+					flags = append(append([]Flag{}, flags...), cmd.Flags...)
+				}
+				if printFlagValueSuggestions(lastArg, flags, c.App.Writer) {
+					return
+				}
 				printFlagSuggestions(lastArg, c.App.Flags, c.App.Writer)
 				if cmd != nil {
 					printFlagSuggestions(lastArg, cmd.Flags, c.App.Writer)
@@ -194,8 +250,10 @@ func DefaultCompleteWithFlags(cmd *Command) func(c *Context) {
 		}
 		if cmd != nil {
 			printCommandSuggestions(cmd.Subcommands, c.App.Writer)
+			printArgumentSuggestions(cmd.Arguments, c.Args().Len(), c.App.Writer)
 		} else {
 			printCommandSuggestions(c.App.Commands, c.App.Writer)
+			printArgumentSuggestions(c.App.Arguments, c.Args().Len(), c.App.Writer)
 		}
 	}
 }
@@ -203,13 +261,14 @@ func DefaultCompleteWithFlags(cmd *Command) func(c *Context) {
 // ShowCommandHelpAndExit - exits with code after showing help
 func ShowCommandHelpAndExit(c *Context, command string, code int) {
 	_ = ShowCommandHelp(c, command)
-	os.Exit(code)
+	OsExiter(code)
 }
 
 // ShowCommandHelp prints help for the given command
 func ShowCommandHelp(ctx *Context, command string) error {
 	// show the subcommand help for a command with subcommands
 	if command == "" {
+		ctx.App.inherited = inheritedFlags(ctx, ctx.App.Flags)
 		HelpPrinter(ctx.App.Writer, SubcommandHelpTemplate, ctx.App)
 		return nil
 	}
@@ -220,6 +279,7 @@ func ShowCommandHelp(ctx *Context, command string) error {
 			if templ == "" {
 				templ = CommandHelpTemplate
 			}
+			c.inherited = inheritedFlags(ctx, c.Flags)
 
 			HelpPrinter(ctx.App.Writer, templ, c)
 
@@ -228,7 +288,7 @@ func ShowCommandHelp(ctx *Context, command string) error {
 	}
 
 	if ctx.App.CommandNotFound == nil {
-		return Exit(fmt.Sprintf("No help topic for '%v'", command), 3)
+		return Exit(fmt.Sprintf("No help topic for '%v'", command)+didYouMean(ctx.App.suggestCommands(command)), 3)
 	}
 
 	ctx.App.CommandNotFound(ctx, command)
@@ -312,7 +372,7 @@ func printHelp(out io.Writer, templ string, data interface{}) {
 func checkVersion(c *Context) bool {
 	found := false
 	for _, name := range VersionFlag.Names() {
-		if c.Bool(name) {
+		if isBoolFlagSet(c, name) {
 			found = true
 		}
 	}
@@ -322,15 +382,24 @@ func checkVersion(c *Context) bool {
 func checkHelp(c *Context) bool {
 	found := false
 	for _, name := range HelpFlag.Names() {
-		if c.Bool(name) {
+		if isBoolFlagSet(c, name) {
 			found = true
 		}
 	}
 	return found
 }
 
+// isBoolFlagSet looks up a bool flag which may not be defined, regardless of
+// StrictLookup
+func isBoolFlagSet(c *Context, name string) bool {
+	if fs := lookupFlagSet(name, c); fs != nil {
+		return lookupBool(name, fs)
+	}
+	return false
+}
+
 func checkCommandHelp(c *Context, name string) bool {
-	if c.Bool("h") || c.Bool("help") {
+	if isBoolFlagSet(c, "h") || isBoolFlagSet(c, "help") {
 		_ = ShowCommandHelp(c, name)
 		return true
 	}
@@ -339,7 +408,7 @@ func checkCommandHelp(c *Context, name string) bool {
 }
 
 func checkSubcommandHelp(c *Context) bool {
-	if c.Bool("h") || c.Bool("help") {
+	if isBoolFlagSet(c, "h") || isBoolFlagSet(c, "help") {
 		_ = ShowSubcommandHelp(c)
 		return true
 	}
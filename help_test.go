@@ -15,6 +15,7 @@
 package cli
 
 import (
+	"errors"
 	"bytes"
 	"flag"
 	"fmt"
@@ -297,7 +298,7 @@ func TestShowCommandHelp_HelpPrinter(t *testing.T) {
 			}
 
 			err := app.Run([]string{"my-app", "help", tt.command})
-			if err != nil {
+			if !errors.Is(err, ErrHelpShown) {
 				t.Fatal(err)
 			}
 
@@ -386,7 +387,7 @@ func TestShowCommandHelp_HelpPrinterCustom(t *testing.T) {
 			}
 
 			err := app.Run([]string{"my-app", "help", tt.command})
-			if err != nil {
+			if !errors.Is(err, ErrHelpShown) {
 				t.Fatal(err)
 			}
 
@@ -398,6 +399,129 @@ func TestShowCommandHelp_HelpPrinterCustom(t *testing.T) {
 	}
 }
 
+func TestShowAppHelp_DeprecatedFlagsAndCommands(t *testing.T) {
+	app := &App{
+		Flags: []Flag{
+			&StringFlag{Name: "host"},
+			&StringFlag{Name: "server", ReplacedBy: "host"},
+		},
+		Commands: []*Command{
+			{
+				Name:   "remove",
+				Action: func(ctx *Context) error { return nil },
+			},
+			{
+				Name:       "rm",
+				Deprecated: "use remove",
+				Action:     func(ctx *Context) error { return nil },
+			},
+		},
+	}
+
+	output := &bytes.Buffer{}
+	app.Writer = output
+	app.Setup()
+	_ = ShowAppHelp(NewContext(app, nil, nil))
+
+	if strings.Contains(output.String(), "--server") {
+		t.Errorf("expected output to exclude \"--server\"; got: %q", output.String())
+	}
+
+	if strings.Contains(output.String(), "rm") {
+		t.Errorf("expected output to exclude \"rm\"; got: %q", output.String())
+	}
+
+	if !strings.Contains(output.String(), "remove") {
+		t.Errorf("expected output to include \"remove\"; got: %q", output.String())
+	}
+}
+
+func TestShowCommandHelp_Arguments(t *testing.T) {
+	app := &App{
+		Commands: []*Command{
+			{
+				Name: "copy",
+				Arguments: []Argument{
+					{Name: "src", Usage: "file to copy", Type: PathArg},
+					{Name: "mode", Type: EnumArg, Allowed: []string{"fast", "safe"}, Optional: true, Value: "safe"},
+					{Name: "dst", Variadic: true},
+				},
+				Action: func(ctx *Context) error {
+					return nil
+				},
+			},
+		},
+	}
+
+	output := &bytes.Buffer{}
+	app.Writer = output
+	app.Setup()
+	_ = ShowCommandHelp(NewContext(app, nil, nil), "copy")
+
+	if !strings.Contains(output.String(), "copy <src> [mode] <dst...>") {
+		t.Errorf("expected output to include the arguments usage; got: %q", output.String())
+	}
+
+	if !strings.Contains(output.String(), "[mode]    (one of: fast, safe) (default: safe)") {
+		t.Errorf("expected output to describe the arguments; got: %q", output.String())
+	}
+}
+
+func TestShowAppHelp_EnvPrefix(t *testing.T) {
+	app := &App{
+		EnvPrefix: "MYAPP",
+		Flags: []Flag{
+			&StringFlag{Name: "db-host", Usage: "database host"},
+			&StringFlag{Name: "token", NoAutoEnv: true},
+		},
+	}
+
+	output := &bytes.Buffer{}
+	app.Writer = output
+	app.Setup()
+	_ = ShowAppHelp(NewContext(app, nil, nil))
+
+	if !strings.Contains(output.String(), "database host [$MYAPP_DB_HOST]") {
+		t.Errorf("expected output to include the derived environment variable; got: %q", output.String())
+	}
+
+	if strings.Contains(output.String(), "MYAPP_TOKEN") {
+		t.Errorf("expected output not to include an environment variable for --token; got: %q", output.String())
+	}
+}
+
+func TestShowCommandHelp_InheritedFlags(t *testing.T) {
+	app := &App{
+		Flags: []Flag{
+			&BoolFlag{Name: "verbose", Usage: "be verbose", Persistent: true},
+			&StringFlag{Name: "region", Usage: "not inherited"},
+		},
+		Commands: []*Command{
+			{
+				Name:  "deploy",
+				Flags: []Flag{&StringFlag{Name: "target"}},
+				Action: func(ctx *Context) error {
+					return nil
+				},
+			},
+		},
+	}
+
+	output := &bytes.Buffer{}
+	app.Writer = output
+	app.Setup()
+	set, _ := app.newFlagSet()
+	_ = ShowCommandHelp(NewContext(app, set, nil), "deploy")
+
+	if !strings.Contains(output.String(), "INHERITED OPTIONS:\n   --verbose  be verbose") {
+		t.Errorf("expected output to include the inherited flags; got: %q", output.String())
+	}
+
+	if strings.Contains(output.String(), "not inherited") {
+		t.Errorf("expected output not to include the flags which are not persistent; got: %q", output.String())
+	}
+}
+
 func TestShowCommandHelp_CommandAliases(t *testing.T) {
 	app := &App{
 		Commands: []*Command{
@@ -424,6 +548,45 @@ func TestShowCommandHelp_CommandAliases(t *testing.T) {
 	}
 }
 
+func TestShowCommandHelp_FlagGroups(t *testing.T) {
+	app := &App{
+		Commands: []*Command{
+			{
+				Name: "login",
+				Flags: []Flag{
+					&StringFlag{Name: "token"},
+					&StringFlag{Name: "token-file"},
+					&StringFlag{Name: "cert"},
+					&StringFlag{Name: "key"},
+				},
+				FlagGroups: []FlagGroup{
+					ExactlyOne("token", "token-file"),
+					Requires("cert", "key"),
+				},
+				Action: func(ctx *Context) error {
+					return nil
+				},
+			},
+		},
+	}
+
+	output := &bytes.Buffer{}
+	app.Writer = output
+	_ = app.Run([]string{"foo", "login"})
+
+	if !strings.Contains(output.String(), "FLAG GROUPS:") {
+		t.Errorf("expected output to include flag groups; got: %q", output.String())
+	}
+
+	if !strings.Contains(output.String(), "(--token | --token-file)  exactly one required") {
+		t.Errorf("expected output to include the group; got: %q", output.String())
+	}
+
+	if !strings.Contains(output.String(), "--cert                    requires --key") {
+		t.Errorf("expected output to include the requires group; got: %q", output.String())
+	}
+}
+
 func TestShowSubcommandHelp_CommandAliases(t *testing.T) {
 	app := &App{
 		Commands: []*Command{
@@ -619,7 +782,7 @@ func TestShowAppHelp_HelpPrinter(t *testing.T) {
 			}
 
 			err := app.Run([]string{"my-app", "help"})
-			if err != nil {
+			if !errors.Is(err, ErrHelpShown) {
 				t.Fatal(err)
 			}
 
@@ -690,7 +853,7 @@ func TestShowAppHelp_HelpPrinterCustom(t *testing.T) {
 			}
 
 			err := app.Run([]string{"my-app", "help"})
-			if err != nil {
+			if !errors.Is(err, ErrHelpShown) {
 				t.Fatal(err)
 			}
 
@@ -0,0 +1,60 @@
+// Copyright 2020 The vine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cli
+
+import "strings"
+
+// Use appends middleware wrapping the action of the app and of all its
+// commands, whichever runs. The middleware of the app wraps the middleware of
+// the commands, and the first middleware given is the outermost one.
+func (a *App) Use(mw ...Middleware) {
+	a.middlewares = append(a.middlewares, mw...)
+}
+
+// Use appends middleware wrapping the action of the command and of all its
+// subcommands, inside the middleware of its ancestors.
+func (c *Command) Use(mw ...Middleware) {
+	c.middlewares = append(c.middlewares, mw...)
+}
+
+// wrapAction wraps action with the middlewares, the first being the
+// outermost one
+func wrapAction(action ActionFunc, middlewares []Middleware) ActionFunc {
+	for i := len(middlewares) - 1; i >= 0; i-- {
+		action = middlewares[i](action)
+	}
+	return action
+}
+
+// chainMiddlewares returns the middlewares of a command appended to the ones
+// of its ancestors
+func chainMiddlewares(ancestors, command []Middleware) []Middleware {
+	chain := make([]Middleware, 0, len(ancestors)+len(command))
+	chain = append(chain, ancestors...)
+	return append(chain, command...)
+}
+
+// CommandPath returns the names of the app and of the commands leading to the
+// command of the context, i.e. "greet config set"
+func (c *Context) CommandPath() string {
+	var path []string
+	if c.App != nil {
+		path = append(path, c.App.Name)
+	}
+	if c.Command != nil && c.Command.Name != "" {
+		path = append(path, c.Command.Name)
+	}
+	return strings.Join(path, " ")
+}
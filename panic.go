@@ -0,0 +1,74 @@
+// Copyright 2020 The vine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cli
+
+import (
+	"fmt"
+	"runtime/debug"
+)
+
+// TracePanicsFlag prints the stack trace of the panics recovered with
+// RecoverPanics. Set to nil to disable the flag.
+var TracePanicsFlag Flag = &BoolFlag{
+	Name:       "trace-panics",
+	Usage:      "print the stack trace of a crash",
+	EnvVars:    []string{"CLI_TRACE_PANICS"},
+	Hidden:     true,
+	Persistent: true,
+}
+
+// PanicError is returned by Run for a panic in Before, Action or After
+// recovered with RecoverPanics
+type PanicError struct {
+	// Value is the value given to panic
+	Value interface{}
+	// Stack is the stack trace of the panic
+	Stack    []byte
+	exitCode int
+}
+
+func (e *PanicError) Error() string {
+	return fmt.Sprintf("unexpected error: %v", e.Value)
+}
+
+// ExitCode returns the PanicExitCode of the app
+func (e *PanicError) ExitCode() int {
+	return e.exitCode
+}
+
+// recoverPanic turns the recovered value into a *PanicError, printing the
+// stack trace if TracePanicsFlag is set, and hands it to the PanicHandler
+// and the exit error handling of the app
+func (a *App) recoverPanic(context *Context, recovered interface{}) error {
+	err := &PanicError{
+		Value:    recovered,
+		Stack:    debug.Stack(),
+		exitCode: a.PanicExitCode,
+	}
+	if err.exitCode == 0 {
+		err.exitCode = 1
+	}
+
+	if TracePanicsFlag != nil && isBoolFlagSet(context, TracePanicsFlag.Names()[0]) {
+		_, _ = fmt.Fprintf(a.errWriter(), "panic: %v\n\n%s\n", recovered, err.Stack)
+	}
+
+	if a.PanicHandler != nil {
+		a.PanicHandler(context, err)
+	}
+
+	a.handleExitCoder(context, err)
+	return err
+}
@@ -16,93 +16,261 @@ package cli
 
 import (
 	"flag"
+	"fmt"
 	"strings"
+	"unicode/utf8"
 )
 
 type iterativeParser interface {
 	newFlagSet() (*flag.FlagSet, error)
 	useShortOptionHandling() bool
+	// isSubcommand reports whether the given argument names a subcommand,
+	// in which case parsing stops and the rest is left to the subcommand
+	isSubcommand(name string) bool
+	// readsValueFromFile reports whether the named flag reads "@path"
+	// values from the file at path
+	readsValueFromFile(name string) bool
+	// persistentFlagSet returns the flag set of the ancestor holding the
+	// named persistent flag, if any
+	persistentFlagSet(name string) *persistentSet
+	// expandFlagName returns the long flag name abbreviated by name, or
+	// name itself
+	expandFlagName(name string) (string, error)
+	// suggestFlags returns the names of the flags close to the unknown
+	// flag name
+	suggestFlags(name string) []string
 }
 
-// To enable short-option handling (e.g., "-it" vs "-i -t") we have to
-// iteratively catch parsing errors. This way we achieve LR parsing without
-// transforming any arguments. Otherwise, there is no way we can discriminate
-// combined short options from common arguments that should be left untouched.
-// Pass `shellComplete` to continue parsing options on failure during shell
-// completion when, the user-supplied options may be incomplete.
+// boolFlag is implemented by flag values that do not require an argument,
+// mirroring the optional interface recognized by the flag package
+type boolFlag interface {
+	flag.Value
+	IsBoolFlag() bool
+}
+
+// parseIter parses args into set following GNU/POSIX conventions:
+//
+//	--name value, --name=value  long options
+//	-n value, -nvalue, -n=value short options (with short-option handling)
+//	-abc                        bundled short bools (with short-option handling)
+//	--                          terminates option parsing
+//
+// Flags and positional arguments may be interleaved. Parsing stops at the
+// first positional argument naming a subcommand, leaving it and everything
+// after it untouched. Without short-option handling a single leading dash
+// names a whole flag, as with the flag package (-name value). The persistent
+// flags of the ancestors are accepted as well, and set in their flag set.
+//
+// The flag set is used as the registry of flag values only; after parsing
+// it reports the positional arguments through Args(). Pass `shellComplete`
+// to ignore errors during shell completion, when the user-supplied options
+// may be incomplete.
 func parseIter(set *flag.FlagSet, ip iterativeParser, args []string, shellComplete bool) error {
-	for {
-		err := set.Parse(args)
-		if !ip.useShortOptionHandling() || err == nil {
-			if shellComplete {
+	p := &argParser{set: set, ip: ip, short: ip.useShortOptionHandling()}
+
+	err := p.parse(args)
+	if shellComplete {
+		err = nil
+	}
+
+	// hand the positional arguments over to the flag set, the terminator
+	// ensures they are not interpreted again
+	if perr := set.Parse(append([]string{"--"}, p.positional...)); err == nil {
+		err = perr
+	}
+	return err
+}
+
+type argParser struct {
+	set        *flag.FlagSet
+	ip         iterativeParser
+	short      bool
+	positional []string
+}
+
+// lookup returns the flag named name and the flag set it belongs to,
+// either the one being parsed or the one of an ancestor
+func (p *argParser) lookup(name string) (*flag.FlagSet, *flag.Flag) {
+	if f := p.set.Lookup(name); f != nil {
+		return p.set, f
+	}
+	if ps := p.ip.persistentFlagSet(name); ps != nil {
+		return ps.set, ps.set.Lookup(name)
+	}
+	return nil, nil
+}
+
+// setFlag sets the flag named name in set, copying the value of a persistent
+// flag to its other names as normalizeFlags does for the local ones
+func (p *argParser) setFlag(set *flag.FlagSet, name, value string) error {
+	if err := set.Set(name, value); err != nil {
+		return err
+	}
+	if set != p.set {
+		p.ip.persistentFlagSet(name).copyAliases(name)
+	}
+	return nil
+}
+
+func (p *argParser) parse(args []string) error {
+	for len(args) > 0 {
+		arg := args[0]
+		args = args[1:]
+
+		var err error
+		switch {
+		case arg == "--":
+			p.positional = append(p.positional, args...)
+			return nil
+		case len(arg) < 2 || arg[0] != '-':
+			if len(p.positional) == 0 && p.ip.isSubcommand(arg) {
+				p.positional = append(p.positional, arg)
+				p.positional = append(p.positional, args...)
 				return nil
 			}
-			return err
+			p.positional = append(p.positional, arg)
+		case arg[1] == '-':
+			args, err = p.parseLong(arg, args)
+		case p.short:
+			args, err = p.parseShort(arg, args)
+		default:
+			args, err = p.parseLong(arg, args)
 		}
 
-		errStr := err.Error()
-		trimmed := strings.TrimPrefix(errStr, "flag provided but not defined: -")
-		if errStr == trimmed {
+		if err != nil {
+			p.positional = append(p.positional, args...)
 			return err
 		}
+	}
 
-		// regenerate the initial args with the split short opts
-		argsWereSplit := false
-		for i, arg := range args {
-			// skip args that are not part of the error message
-			if name := strings.TrimPrefix(arg, "-"); name != trimmed {
-				continue
-			}
+	return nil
+}
 
-			// if we can't split, the error was accurate
-			shortOpts := splitShortOptions(set, arg)
-			if len(shortOpts) == 1 {
-				return err
-			}
+// parseLong handles a single named flag, "--name[=value]" or, without
+// short-option handling, "-name[=value]"
+func (p *argParser) parseLong(arg string, args []string) ([]string, error) {
+	dashes := "-"
+	if arg[1] == '-' {
+		dashes = "--"
+	}
+
+	name := arg[len(dashes):]
+	if len(name) == 0 || name[0] == '-' || name[0] == '=' {
+		return args, fmt.Errorf("bad flag syntax: %s", arg)
+	}
+
+	value, hasValue := "", false
+	if i := strings.Index(name, "="); i >= 0 {
+		name, value, hasValue = name[:i], name[i+1:], true
+	}
 
-			// swap current argument with the split version
-			args = append(args[:i], append(shortOpts, args[i+1:]...)...)
-			argsWereSplit = true
-			break
+	set, f := p.lookup(name)
+	if f == nil && len(name) > 1 {
+		full, err := p.ip.expandFlagName(name)
+		if err != nil {
+			return args, err
 		}
+		name = full
+		set, f = p.lookup(name)
+	}
+	if f == nil {
+		return args, p.undefinedFlagError(dashes, name)
+	}
 
-		// This should be an impossible to reach code path, but in case the arg
-		// splitting failed to happen, this will prevent infinite loops
-		if !argsWereSplit {
-			return err
+	if isBoolValue(f.Value) {
+		if !hasValue {
+			value = "true"
 		}
+		return args, p.setBool(set, dashes+name, value)
+	}
 
-		// Since custom parsing failed, replace the flag set before retrying
-		newSet, err := ip.newFlagSet()
-		if err != nil {
-			return err
+	if !hasValue {
+		if len(args) == 0 {
+			return args, fmt.Errorf("flag needs an argument: %s%s", dashes, name)
 		}
-		*set = *newSet
+		value, args = args[0], args[1:]
 	}
+
+	return args, p.setValue(set, dashes+name, value)
 }
 
-func splitShortOptions(set *flag.FlagSet, arg string) []string {
-	shortFlagsExist := func(s string) bool {
-		for _, c := range s[1:] {
-			if f := set.Lookup(string(c)); f == nil {
-				return false
+// parseShort handles a cluster of single character flags, "-abc", where the
+// first flag taking a value consumes the remainder of the cluster or the
+// next argument
+func (p *argParser) parseShort(arg string, args []string) ([]string, error) {
+	shorts := arg[1:]
+	if shorts[0] == '=' {
+		return args, fmt.Errorf("bad flag syntax: %s", arg)
+	}
+
+	for len(shorts) > 0 {
+		c, size := utf8.DecodeRuneInString(shorts)
+		name := string(c)
+		shorts = shorts[size:]
+
+		set, f := p.lookup(name)
+		if f == nil {
+			return args, p.undefinedFlagError("-", name)
+		}
+
+		if isBoolValue(f.Value) {
+			if strings.HasPrefix(shorts, "=") {
+				return args, p.setBool(set, "-"+name, shorts[1:])
+			}
+			if err := p.setBool(set, "-"+name, "true"); err != nil {
+				return args, err
+			}
+			continue
+		}
+
+		value := strings.TrimPrefix(shorts, "=")
+		if len(shorts) == 0 {
+			if len(args) == 0 {
+				return args, fmt.Errorf("flag needs an argument: -%s", name)
 			}
+			value, args = args[0], args[1:]
 		}
-		return true
+
+		return args, p.setValue(set, "-"+name, value)
 	}
 
-	if !isSplittable(arg) || !shortFlagsExist(arg) {
-		return []string{arg}
+	return args, nil
+}
+
+func (p *argParser) setBool(set *flag.FlagSet, name, value string) error {
+	if err := p.setFlag(set, strings.TrimLeft(name, "-"), value); err != nil {
+		return fmt.Errorf("invalid boolean value %q for %s (from command line): %v", value, name, err)
 	}
+	return nil
+}
 
-	separated := make([]string, 0, len(arg)-1)
-	for _, flagChar := range arg[1:] {
-		separated = append(separated, "-"+string(flagChar))
+func (p *argParser) setValue(set *flag.FlagSet, name, value string) error {
+	if len(value) > 1 && value[0] == '@' && p.ip.readsValueFromFile(strings.TrimLeft(name, "-")) {
+		content, err := readValueFile(value[1:])
+		if err != nil {
+			return fmt.Errorf("could not read value for flag %s: %v", name, err)
+		}
+		value = content
+	}
+	if err := p.setFlag(set, strings.TrimLeft(name, "-"), value); err != nil {
+		return fmt.Errorf("invalid value %q for flag %s (from command line): %v", value, name, err)
 	}
+	return nil
+}
 
-	return separated
+// undefinedFlagError reports an unknown flag along with the flags close to
+// it, a help request is reported as flag.ErrHelp in the same way the flag
+// package does
+func (p *argParser) undefinedFlagError(dashes, name string) error {
+	if name == "help" || name == "h" {
+		return flag.ErrHelp
+	}
+	return &UnknownFlagError{Name: dashes + name, Suggestions: p.ip.suggestFlags(name)}
 }
 
-func isSplittable(flagArg string) bool {
-	return strings.HasPrefix(flagArg, "-") && !strings.HasPrefix(flagArg, "--") && len(flagArg) > 2
+func isBoolValue(v flag.Value) bool {
+	if bf, ok := v.(boolFlag); ok {
+		return bf.IsBoolFlag()
+	}
+	return false
 }
@@ -0,0 +1,150 @@
+// Copyright 2020 The vine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cli
+
+import (
+	"flag"
+	"reflect"
+)
+
+// persistentSet is the flag set of an ancestor holding a persistent flag
+type persistentSet struct {
+	set  *flag.FlagSet
+	flag Flag
+}
+
+// copyAliases copies the value of the flag set under name to its other names
+func (ps *persistentSet) copyAliases(name string) {
+	ff := ps.set.Lookup(name)
+	for _, alias := range ps.flag.Names() {
+		if alias != name {
+			copyFlag(alias, ff, ps.set)
+		}
+	}
+}
+
+func isFlagPersistent(f Flag) bool {
+	v := flagValue(f)
+	if v.Kind() != reflect.Struct {
+		return false
+	}
+	field := v.FieldByName("Persistent")
+	return field.IsValid() && field.Bool()
+}
+
+// contextFlags returns the flags defined at the level of the context
+func contextFlags(ctx *Context) []Flag {
+	var flags []Flag
+	if ctx.Command != nil {
+		flags = append(flags, ctx.Command.Flags...)
+	}
+	if ctx.App != nil {
+		flags = append(flags, ctx.App.Flags...)
+	}
+	return flags
+}
+
+// persistentFlagSets maps the names of the persistent flags of ctx and of
+// its parents to the flag set holding them, the closest one first
+func persistentFlagSets(ctx *Context) map[string]*persistentSet {
+	sets := map[string]*persistentSet{}
+	for _, cur := range ctx.Lineage() {
+		if cur.flagSet == nil {
+			continue
+		}
+		for _, f := range contextFlags(cur) {
+			if !isFlagPersistent(f) || cur.flagSet.Lookup(f.Names()[0]) == nil {
+				continue
+			}
+			for _, name := range f.Names() {
+				if _, ok := sets[name]; !ok {
+					sets[name] = &persistentSet{set: cur.flagSet, flag: f}
+				}
+			}
+		}
+	}
+	return sets
+}
+
+// readsValueFromFile reports whether the flag named name sets ValueFromFile,
+// looking it up in the local flags first, then in the persistent flags of
+// the ancestors
+func readsValueFromFile(local []Flag, persistent map[string]*persistentSet, name string) bool {
+	for _, f := range local {
+		for _, n := range f.Names() {
+			if n == name {
+				return flagReadsValueFromFile([]Flag{f}, name)
+			}
+		}
+	}
+	if ps := persistent[name]; ps != nil {
+		return flagReadsValueFromFile([]Flag{ps.flag}, name)
+	}
+	return false
+}
+
+// inheritedFlags returns the persistent flags of ctx and of its parents
+// which are not shadowed by one of the local flags
+func inheritedFlags(ctx *Context, local []Flag) []Flag {
+	if ctx == nil {
+		return nil
+	}
+
+	defined := map[string]bool{}
+	for _, f := range local {
+		for _, name := range f.Names() {
+			defined[name] = true
+		}
+	}
+
+	var inherited []Flag
+	for _, cur := range ctx.Lineage() {
+		if cur.flagSet == nil {
+			continue
+		}
+		for _, f := range contextFlags(cur) {
+			if !isFlagPersistent(f) || defined[f.Names()[0]] || cur.flagSet.Lookup(f.Names()[0]) == nil {
+				continue
+			}
+			for _, name := range f.Names() {
+				defined[name] = true
+			}
+			inherited = append(inherited, f)
+		}
+	}
+	return inherited
+}
+
+// withoutPersistentFlags returns the flags which are not persistent, the
+// checks of the persistent ones being left to the command which runs
+func withoutPersistentFlags(flags []Flag) []Flag {
+	var local []Flag
+	for _, f := range flags {
+		if !isFlagPersistent(f) {
+			local = append(local, f)
+		}
+	}
+	return local
+}
+
+// checkedFlags returns the flags checked before running a level. When a
+// subcommand follows, the persistent flags are left to it, as they may still
+// be set after its name, otherwise the inherited ones are checked as well
+func checkedFlags(flags []Flag, ctx *Context, subcommand bool) []Flag {
+	if subcommand {
+		return withoutPersistentFlags(flags)
+	}
+	return append(append([]Flag{}, flags...), inheritedFlags(ctx.parentContext, flags)...)
+}
@@ -0,0 +1,206 @@
+// Copyright 2020 The vine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cli
+
+import (
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+)
+
+// stdin is read by the flags taking their value from "@-"
+var stdin io.Reader = os.Stdin
+
+// expandResponseFiles replaces every "@path" argument with the arguments
+// read from the file at path, recursively. Arguments after "--", and the
+// values of the flags reading their value from a file, are left untouched.
+func (a *App) expandResponseFiles(args []string) ([]string, error) {
+	expanded, _, err := a.expandArgs(args, nil)
+	return expanded, err
+}
+
+func (a *App) expandArgs(args []string, files []string) ([]string, bool, error) {
+	var expanded []string
+	for i, arg := range args {
+		switch {
+		case arg == "--":
+			return append(expanded, args[i:]...), true, nil
+		case len(arg) < 2 || arg[0] != '@':
+			expanded = append(expanded, arg)
+			continue
+		case i > 0 && a.isValueFromFileArg(args[i-1]):
+			expanded = append(expanded, arg)
+			continue
+		}
+
+		path := arg[1:]
+		abs, err := filepath.Abs(path)
+		if err != nil {
+			return nil, false, err
+		}
+		for _, file := range files {
+			if file == abs {
+				return nil, false, fmt.Errorf("response file %q includes itself", path)
+			}
+		}
+
+		content, err := ioutil.ReadFile(path)
+		if err != nil {
+			return nil, false, fmt.Errorf("could not read response file: %v", err)
+		}
+		fileArgs, err := splitResponseFile(string(content))
+		if err != nil {
+			return nil, false, fmt.Errorf("could not parse response file %q: %v", path, err)
+		}
+
+		fileArgs, terminated, err := a.expandArgs(fileArgs, append(files, abs))
+		if err != nil {
+			return nil, false, err
+		}
+		expanded = append(expanded, fileArgs...)
+		if terminated {
+			return append(expanded, args[i+1:]...), true, nil
+		}
+	}
+	return expanded, false, nil
+}
+
+// isValueFromFileArg reports whether arg names a flag of the app, or of any
+// of its commands, reading its value from a file
+func (a *App) isValueFromFileArg(arg string) bool {
+	if len(arg) < 2 || arg[0] != '-' || strings.Contains(arg, "=") {
+		return false
+	}
+	name := strings.TrimLeft(arg, "-")
+	return flagReadsValueFromFile(a.Flags, name) || commandsReadValueFromFile(a.Commands, name)
+}
+
+func commandsReadValueFromFile(commands []*Command, name string) bool {
+	for _, c := range commands {
+		if flagReadsValueFromFile(c.Flags, name) || commandsReadValueFromFile(c.Subcommands, name) {
+			return true
+		}
+	}
+	return false
+}
+
+// flagReadsValueFromFile reports whether the flag named name sets
+// ValueFromFile
+func flagReadsValueFromFile(flags []Flag, name string) bool {
+	for _, f := range flags {
+		for _, n := range f.Names() {
+			if n != name {
+				continue
+			}
+			v := flagValue(f)
+			if v.Kind() != reflect.Struct {
+				return false
+			}
+			field := v.FieldByName("ValueFromFile")
+			return field.IsValid() && field.Bool()
+		}
+	}
+	return false
+}
+
+// readValueFile returns the content of the file at path, or of stdin when
+// path is "-", without its trailing newline
+func readValueFile(path string) (string, error) {
+	var content []byte
+	var err error
+	if path == "-" {
+		content, err = ioutil.ReadAll(stdin)
+	} else {
+		content, err = ioutil.ReadFile(path)
+	}
+	if err != nil {
+		return "", err
+	}
+	value := strings.TrimSuffix(string(content), "\n")
+	return strings.TrimSuffix(value, "\r"), nil
+}
+
+// splitResponseFile splits content into arguments the way a POSIX shell
+// does: arguments are separated by white space, which single or double
+// quotes and backslashes preserve, and a "#" starting an argument comments
+// out the rest of the line
+func splitResponseFile(content string) ([]string, error) {
+	var args []string
+	var arg strings.Builder
+	inArg := false
+
+	runes := []rune(content)
+	for i := 0; i < len(runes); i++ {
+		r := runes[i]
+		switch {
+		case r == ' ' || r == '\t' || r == '\n' || r == '\r':
+			if inArg {
+				args = append(args, arg.String())
+				arg.Reset()
+				inArg = false
+			}
+		case r == '#' && !inArg:
+			for i < len(runes) && runes[i] != '\n' {
+				i++
+			}
+		case r == '\\':
+			inArg = true
+			if i+1 < len(runes) {
+				i++
+				if runes[i] == '\n' {
+					continue
+				}
+				arg.WriteRune(runes[i])
+			}
+		case r == '\'':
+			inArg = true
+			end := i + 1
+			for end < len(runes) && runes[end] != '\'' {
+				end++
+			}
+			if end == len(runes) {
+				return nil, fmt.Errorf("unterminated single quote")
+			}
+			arg.WriteString(string(runes[i+1 : end]))
+			i = end
+		case r == '"':
+			inArg = true
+			i++
+			for ; i < len(runes) && runes[i] != '"'; i++ {
+				if runes[i] == '\\' && i+1 < len(runes) && strings.ContainsRune("\"\\$`\n", runes[i+1]) {
+					i++
+					if runes[i] == '\n' {
+						continue
+					}
+				}
+				arg.WriteRune(runes[i])
+			}
+			if i == len(runes) {
+				return nil, fmt.Errorf("unterminated double quote")
+			}
+		default:
+			inArg = true
+			arg.WriteRune(r)
+		}
+	}
+	if inArg {
+		args = append(args, arg.String())
+	}
+	return args, nil
+}
@@ -0,0 +1,228 @@
+// Copyright 2020 The vine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cli
+
+import (
+	"fmt"
+	"io/ioutil"
+	"strings"
+	"syscall"
+	"text/tabwriter"
+)
+
+// ValueSourceKind tells where the value of a flag came from
+type ValueSourceKind int
+
+const (
+	// DefaultSource is the default value of the flag, it is not set
+	DefaultSource ValueSourceKind = iota
+	// CommandLineSource is the command line
+	CommandLineSource
+	// EnvVarSource is the environment variable Name
+	EnvVarSource
+	// FileSource is the file Name of the FilePath of the flag
+	FileSource
+	// AltInputSource is the key Key of the alternate input source Name
+	AltInputSource
+)
+
+// ValueSource describes where the value of a flag came from
+type ValueSource struct {
+	Kind ValueSourceKind
+	Name string
+	Key  string
+}
+
+// String returns a readable representation of the source, i.e.
+// "command line", "$APP_PORT" or "config.yaml key port"
+func (s ValueSource) String() string {
+	switch s.Kind {
+	case CommandLineSource:
+		return "command line"
+	case EnvVarSource:
+		return "$" + s.Name
+	case FileSource:
+		return "file " + s.Name
+	case AltInputSource:
+		name := s.Name
+		if name == "" {
+			name = "input source"
+		}
+		if s.Key != "" {
+			name += " key " + s.Key
+		}
+		return name
+	}
+	return "default"
+}
+
+// DebugFlagsFlag prints the value and the source of every flag instead of
+// running the action. Set to nil to disable the flag.
+var DebugFlagsFlag Flag = &BoolFlag{
+	Name:   "debug-flags",
+	Usage:  "print the resolved flags with their sources",
+	Hidden: true,
+}
+
+// Source returns where the value of the flag named name came from, the
+// zero ValueSource being the default value
+func (c *Context) Source(name string) ValueSource {
+	for _, ctx := range c.Lineage() {
+		if ctx.flagSet == nil || ctx.flagSet.Lookup(name) == nil {
+			continue
+		}
+		if source, ok := ctx.sources[name]; ok {
+			return source
+		}
+		if f := lookupFlag(name, ctx); f != nil {
+			return flagSource(f, ctx)
+		}
+		if isFlagVisited(ctx.flagSet, name) {
+			return ValueSource{Kind: CommandLineSource}
+		}
+		break
+	}
+	return ValueSource{}
+}
+
+// SetSource records where the value of the flag named name came from, for
+// the values set by other means than the command line, the environment or
+// files, i.e. alternate input sources
+func (c *Context) SetSource(name string, source ValueSource) {
+	for _, ctx := range c.Lineage() {
+		if ctx.flagSet == nil || ctx.flagSet.Lookup(name) == nil {
+			continue
+		}
+		names := []string{name}
+		if f := lookupFlag(name, ctx); f != nil {
+			names = f.Names()
+		}
+		if ctx.sources == nil {
+			ctx.sources = map[string]ValueSource{}
+		}
+		for _, n := range names {
+			ctx.sources[n] = source
+		}
+		return
+	}
+}
+
+// flagSource returns where the value of the flag in the flag set of the
+// context came from
+func flagSource(f Flag, context *Context) ValueSource {
+	for _, name := range f.Names() {
+		if source, ok := context.sources[name]; ok {
+			return source
+		}
+	}
+
+	for _, name := range f.Names() {
+		if fs := lookupFlagSet(name, context); fs != nil && isFlagVisited(fs, name) {
+			return ValueSource{Kind: CommandLineSource}
+		}
+	}
+
+	// Apply marks the flag as set only when it used a value from the
+	// environment or a file, an empty value being ignored by most flags
+	if !f.IsSet() {
+		return ValueSource{}
+	}
+	return envOrFileSource(f)
+}
+
+// envOrFileSource returns the environment variable or the file Apply reads
+// the value of the flag from, if any
+func envOrFileSource(f Flag) ValueSource {
+	var filePath string
+	if fp := flagValue(f).FieldByName("FilePath"); fp.IsValid() {
+		filePath = fp.String()
+	}
+	_, source, _ := flagFromEnvOrFileSource(flagStringSliceField(f, "EnvVars"), filePath)
+	return source
+}
+
+// flagFromEnvOrFileSource is like flagFromEnvOrFile, also returning the
+// environment variable or the file the value was read from
+func flagFromEnvOrFileSource(envVars []string, filePath string) (string, ValueSource, bool) {
+	for _, envVar := range envVars {
+		envVar = strings.TrimSpace(envVar)
+		if val, ok := syscall.Getenv(envVar); ok {
+			return val, ValueSource{Kind: EnvVarSource, Name: envVar}, true
+		}
+	}
+	for _, fileVar := range strings.Split(filePath, ",") {
+		if data, err := ioutil.ReadFile(fileVar); err == nil {
+			return string(data), ValueSource{Kind: FileSource, Name: fileVar}, true
+		}
+	}
+	return "", ValueSource{}, false
+}
+
+// checkDebugFlags prints the flags with their sources if DebugFlagsFlag is
+// set
+func checkDebugFlags(c *Context) bool {
+	if DebugFlagsFlag == nil {
+		return false
+	}
+	name := DebugFlagsFlag.Names()[0]
+	if lookupFlagSet(name, c) == nil || !c.Bool(name) {
+		return false
+	}
+
+	printFlagSources(c)
+	return true
+}
+
+// printFlagSources prints a table of the flags of the context and of its
+// parents, from the outermost one, with their values and sources
+func printFlagSources(c *Context) {
+	w := tabwriter.NewWriter(c.App.Writer, 1, 8, 2, ' ', 0)
+	_, _ = fmt.Fprintln(w, "FLAG\tVALUE\tSOURCE")
+
+	lineage := c.Lineage()
+	for i := len(lineage) - 1; i >= 0; i-- {
+		ctx := lineage[i]
+		if ctx.flagSet == nil || ctx.App == nil {
+			continue
+		}
+		flags := ctx.App.Flags
+		if ctx.Command != nil && ctx.Command.Name != "" {
+			flags = ctx.Command.Flags
+		}
+
+		for _, f := range flags {
+			if isBuiltinFlag(f) {
+				continue
+			}
+			name := f.Names()[0]
+			fl := ctx.flagSet.Lookup(name)
+			if fl == nil {
+				continue
+			}
+			_, _ = fmt.Fprintf(w, "%s\t%s\t%s\n", prefixFor(name)+name, fl.Value.String(), flagSource(f, ctx))
+		}
+	}
+
+	_ = w.Flush()
+}
+
+func isBuiltinFlag(f Flag) bool {
+	for _, builtin := range []Flag{HelpFlag, VersionFlag, BashCompletionFlag, DebugFlagsFlag, TracePanicsFlag} {
+		if f == builtin {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,216 @@
+// Copyright 2020 The vine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cli
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// defaultSuggestionsDistance is the edit distance of the suggestions when
+// SuggestionsMinDistance is not set
+const defaultSuggestionsDistance = 2
+
+// UnknownCommandError is the usage error of an unknown command, along with
+// the names of the commands close to it
+type UnknownCommandError struct {
+	Name        string
+	Suggestions []string
+}
+
+func (e *UnknownCommandError) Error() string {
+	return fmt.Sprintf("Unknown command %q", e.Name) + didYouMean(e.Suggestions)
+}
+
+// UnknownFlagError is the usage error of an unknown flag, along with the
+// names of the flags close to it
+type UnknownFlagError struct {
+	Name        string
+	Suggestions []string
+}
+
+func (e *UnknownFlagError) Error() string {
+	return "flag provided but not defined: " + e.Name + didYouMean(e.Suggestions)
+}
+
+func didYouMean(suggestions []string) string {
+	switch len(suggestions) {
+	case 0:
+		return ""
+	case 1:
+		return fmt.Sprintf(". Did you mean %q?", suggestions[0])
+	}
+
+	quoted := make([]string, len(suggestions))
+	for i, s := range suggestions {
+		quoted[i] = fmt.Sprintf("%q", s)
+	}
+	return fmt.Sprintf(". Did you mean one of %s?", strings.Join(quoted, ", "))
+}
+
+// CommandSuggestions returns the names and aliases of the visible commands
+// of the app close to name, the closest first
+func (c *Context) CommandSuggestions(name string) []string {
+	if c.App == nil {
+		return nil
+	}
+	return c.App.suggestCommands(name)
+}
+
+// suggestionsDistance returns the maximum edit distance of the suggestions,
+// or -1 when they are disabled
+func (a *App) suggestionsDistance() int {
+	if a.DisableSuggestions {
+		return -1
+	}
+	if a.SuggestionsMinDistance <= 0 {
+		return defaultSuggestionsDistance
+	}
+	return a.SuggestionsMinDistance
+}
+
+func (a *App) suggestCommands(name string) []string {
+	var names []string
+	for _, c := range a.VisibleCommands() {
+		names = append(names, c.Names()...)
+	}
+	return suggest(name, names, a.suggestionsDistance())
+}
+
+// suggestFlags returns the names and aliases of the visible flags, local or
+// persistent, close to name
+func suggestFlags(flags []Flag, persistent map[string]*persistentSet, name string, distance int) []string {
+	if len(name) < 2 {
+		return nil
+	}
+
+	// single character names are too short to tell what was meant
+	var names []string
+	for _, f := range visibleFlags(flags) {
+		for _, n := range f.Names() {
+			if len(n) > 1 {
+				names = append(names, n)
+			}
+		}
+	}
+	for n, ps := range persistent {
+		if len(n) > 1 && len(visibleFlags([]Flag{ps.flag})) > 0 {
+			names = append(names, n)
+		}
+	}
+
+	var suggestions []string
+	for _, n := range suggest(name, names, distance) {
+		suggestions = append(suggestions, prefixFor(n)+n)
+	}
+	return suggestions
+}
+
+// suggest returns the candidates within the edit distance of name, or
+// starting with it, the closest first
+func suggest(name string, candidates []string, distance int) []string {
+	if distance < 0 || name == "" {
+		return nil
+	}
+
+	distances := map[string]int{}
+	lower := strings.ToLower(name)
+	for _, candidate := range candidates {
+		lowerCandidate := strings.ToLower(candidate)
+		d := editDistance(lower, lowerCandidate)
+		if d <= distance || strings.HasPrefix(lowerCandidate, lower) {
+			distances[candidate] = d
+		}
+	}
+
+	var suggestions []string
+	for candidate := range distances {
+		suggestions = append(suggestions, candidate)
+	}
+	sort.Slice(suggestions, func(i, j int) bool {
+		di, dj := distances[suggestions[i]], distances[suggestions[j]]
+		if di != dj {
+			return di < dj
+		}
+		return suggestions[i] < suggestions[j]
+	})
+	return suggestions
+}
+
+// editDistance returns the Levenshtein distance between a and b
+func editDistance(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	previous := make([]int, len(rb)+1)
+	current := make([]int, len(rb)+1)
+	for j := range previous {
+		previous[j] = j
+	}
+
+	for i := 1; i <= len(ra); i++ {
+		current[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			current[j] = min3(previous[j]+1, current[j-1]+1, previous[j-1]+cost)
+		}
+		previous, current = current, previous
+	}
+	return previous[len(rb)]
+}
+
+func min3(a, b, c int) int {
+	if b < a {
+		a = b
+	}
+	if c < a {
+		a = c
+	}
+	return a
+}
+
+// hasDefaultAction reports whether the app runs the built-in help action,
+// having no action of its own
+func (a *App) hasDefaultAction() bool {
+	action := reflect.ValueOf(a.Action).Pointer()
+	return action == reflect.ValueOf(helpCommand.Action).Pointer() ||
+		action == reflect.ValueOf(helpSubcommand.Action).Pointer()
+}
+
+// commandNotFound handles an unknown command, with CommandNotFound when it
+// is set, or as a usage error
+func (a *App) commandNotFound(context *Context, name string, isSubcommand bool) error {
+	if a.CommandNotFound != nil {
+		a.CommandNotFound(context, name)
+		return nil
+	}
+
+	err := error(&UnknownCommandError{Name: name, Suggestions: a.suggestCommands(name)})
+	if a.OnUsageError != nil {
+		err = a.OnUsageError(context, err, isSubcommand)
+		a.handleExitCoder(context, err)
+		return err
+	}
+	_, _ = fmt.Fprintf(a.Writer, "%s %s\n\n", "Incorrect Usage.", err.Error())
+	if isSubcommand {
+		_ = ShowSubcommandHelp(context)
+	} else {
+		_ = ShowAppHelp(context)
+	}
+	return err
+}
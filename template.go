@@ -21,7 +21,7 @@ var AppHelpTemplate = `NAME:
    {{.Name}}{{if .Usage}} - {{.Usage}}{{end}}
 
 USAGE:
-   {{if .UsageText}}{{.UsageText}}{{else}}{{.HelpName}} {{if .VisibleFlags}}[global options]{{end}}{{if .Commands}} command [command options]{{end}} {{if .ArgsUsage}}{{.ArgsUsage}}{{else}}[arguments...]{{end}}{{end}}{{if .Version}}{{if not .HideVersion}}
+   {{if .UsageText}}{{.UsageText}}{{else}}{{.HelpName}} {{if .VisibleFlags}}[global options]{{end}}{{if .Commands}} command [command options]{{end}} {{if .ArgumentsUsage}}{{.ArgumentsUsage}}{{else}}[arguments...]{{end}}{{end}}{{if .Version}}{{if not .HideVersion}}
 
 VERSION:
    {{.Version}}{{end}}{{end}}{{if .Description}}
@@ -36,11 +36,19 @@ AUTHOR{{with $length := len .Authors}}{{if ne 1 $length}}S{{end}}{{end}}:
 COMMANDS:{{range .VisibleCategories}}{{if .Name}}
    {{.Name}}:{{range .VisibleCommands}}
      {{join .Names ", "}}{{"\t"}}{{.Usage}}{{end}}{{else}}{{range .VisibleCommands}}
-   {{join .Names ", "}}{{"\t"}}{{.Usage}}{{end}}{{end}}{{end}}{{end}}{{if .VisibleFlags}}
+   {{join .Names ", "}}{{"\t"}}{{.Usage}}{{end}}{{end}}{{end}}{{end}}{{if .Arguments}}
+
+ARGUMENTS:
+   {{range $index, $argument := .Arguments}}{{if $index}}
+   {{end}}{{$argument}}{{end}}{{end}}{{if .VisibleFlags}}
 
 GLOBAL OPTIONS:
    {{range $index, $option := .VisibleFlags}}{{if $index}}
-   {{end}}{{$option}}{{end}}{{end}}{{if .Copyright}}
+   {{end}}{{$option}}{{end}}{{end}}{{if .FlagGroups}}
+
+FLAG GROUPS:
+   {{range $index, $group := .FlagGroups}}{{if $index}}
+   {{end}}{{$group}}{{end}}{{end}}{{if .Copyright}}
 
 COPYRIGHT:
    {{.Copyright}}{{end}}
@@ -60,16 +68,26 @@ var CommandHelpTemplate = `NAME:
    {{.HelpName}} - {{.Usage}}
 
 USAGE:
-   {{if .UsageText}}{{.UsageText}}{{else}}{{.HelpName}}{{if .VisibleFlags}} [command options]{{end}} {{if .ArgsUsage}}{{.ArgsUsage}}{{else}}[arguments...]{{end}}{{end}}{{if .Category}}
+   {{if .UsageText}}{{.UsageText}}{{else}}{{.HelpName}}{{if .VisibleFlags}} [command options]{{end}} {{if .ArgumentsUsage}}{{.ArgumentsUsage}}{{else}}[arguments...]{{end}}{{end}}{{if .Category}}
 
 CATEGORY:
    {{.Category}}{{end}}{{if .Description}}
 
 DESCRIPTION:
-   {{.Description}}{{end}}{{if .VisibleFlags}}
+   {{.Description}}{{end}}{{if .Arguments}}
+
+ARGUMENTS:
+   {{range $index, $argument := .Arguments}}{{if $index}}
+   {{end}}{{$argument}}{{end}}{{end}}{{if .VisibleFlags}}
 
 OPTIONS:
    {{range .VisibleFlags}}{{.}}
+   {{end}}{{end}}{{if .VisibleInheritedFlags}}
+INHERITED OPTIONS:
+   {{range .VisibleInheritedFlags}}{{.}}
+   {{end}}{{end}}{{if .FlagGroups}}
+FLAG GROUPS:
+   {{range .FlagGroups}}{{.}}
    {{end}}{{end}}
 `
 
@@ -80,15 +98,25 @@ var SubcommandHelpTemplate = `NAME:
    {{.HelpName}} - {{if .Description}}{{.Description}}{{else}}{{.Usage}}{{end}}
 
 USAGE:
-   {{if .UsageText}}{{.UsageText}}{{else}}{{.HelpName}} command{{if .VisibleFlags}} [command options]{{end}} {{if .ArgsUsage}}{{.ArgsUsage}}{{else}}[arguments...]{{end}}{{end}}
+   {{if .UsageText}}{{.UsageText}}{{else}}{{.HelpName}} command{{if .VisibleFlags}} [command options]{{end}} {{if .ArgumentsUsage}}{{.ArgumentsUsage}}{{else}}[arguments...]{{end}}{{end}}
 
 COMMANDS:{{range .VisibleCategories}}{{if .Name}}
    {{.Name}}:{{range .VisibleCommands}}
      {{join .Names ", "}}{{"\t"}}{{.Usage}}{{end}}{{else}}{{range .VisibleCommands}}
-   {{join .Names ", "}}{{"\t"}}{{.Usage}}{{end}}{{end}}{{end}}{{if .VisibleFlags}}
+   {{join .Names ", "}}{{"\t"}}{{.Usage}}{{end}}{{end}}{{end}}{{if .Arguments}}
+
+ARGUMENTS:
+   {{range $index, $argument := .Arguments}}{{if $index}}
+   {{end}}{{$argument}}{{end}}{{end}}{{if .VisibleFlags}}
 
 OPTIONS:
    {{range .VisibleFlags}}{{.}}
+   {{end}}{{end}}{{if .VisibleInheritedFlags}}
+INHERITED OPTIONS:
+   {{range .VisibleInheritedFlags}}{{.}}
+   {{end}}{{end}}{{if .FlagGroups}}
+FLAG GROUPS:
+   {{range .FlagGroups}}{{.}}
    {{end}}{{end}}
 `
 
@@ -114,13 +142,24 @@ var MarkdownDocTemplate = `% {{ .App.Name }} 8
 ` + "```" + `
 {{ .App.Name }} [GLOBAL OPTIONS] command [COMMAND OPTIONS] [ARGUMENTS...]
 ` + "```" + `
-{{ if .GlobalArgs }}
+{{ if .Arguments }}
+# ARGUMENTS
+{{ range $v := .Arguments }}
+{{ $v }}{{ end }}
+{{ end }}{{ if .GlobalArgs }}
 # GLOBAL OPTIONS
 {{ range $v := .GlobalArgs }}
 {{ $v }}{{ end }}
+{{ end }}{{ if .FlagGroups }}
+# FLAG GROUPS
+{{ range $v := .FlagGroups }}
+{{ $v }}{{ end }}
 {{ end }}{{ if .Commands }}
 # COMMANDS
 {{ range $v := .Commands }}
+{{ $v }}{{ end }}{{ end }}{{ if .EnvVars }}
+# ENVIRONMENT
+{{ range $v := .EnvVars }}
 {{ $v }}{{ end }}{{ end }}`
 
 var FishCompletionTemplate = `# {{ .App.Name }} fish shell completion
@@ -0,0 +1,153 @@
+// Copyright 2020 The vine Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cli
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// Validate checks the definitions of the commands and the flags of the app
+// and of all its subcommands, without running any of them. Every problem
+// found is reported with the path of its command in the returned
+// MultiError, i.e. duplicated names, a required flag with a default value,
+// or a Destination shared by two flags.
+func (a *App) Validate() error {
+	a.Setup()
+
+	v := &treeValidator{destinations: map[uintptr]flagOwner{}}
+	inherited := v.validateFlags(a.Name, a.Flags, map[string]string{})
+	v.validateCommands(a.Name, a.Commands, inherited)
+
+	if len(v.errs) == 0 {
+		return nil
+	}
+	return newMultiError(v.errs...)
+}
+
+// flagOwner is a flag and the path of the command defining it
+type flagOwner struct {
+	path string
+	flag Flag
+}
+
+// treeValidator collects the problems found by Validate
+type treeValidator struct {
+	destinations map[uintptr]flagOwner
+	errs         []error
+}
+
+func (v *treeValidator) errorf(path, format string, a ...interface{}) {
+	v.errs = append(v.errs, fmt.Errorf("%s: %s", path, fmt.Sprintf(format, a...)))
+}
+
+// validateFlags checks the flags of the command at path against each other
+// and against the persistent flags inherited from its ancestors, which map
+// the names to the path of the command defining them. It returns the
+// persistent flags inherited by the subcommands.
+func (v *treeValidator) validateFlags(path string, flags []Flag, inherited map[string]string) map[string]string {
+	seen := map[string]bool{}
+	persistent := make(map[string]string, len(inherited))
+	for name, owner := range inherited {
+		persistent[name] = owner
+	}
+
+	for i, f := range flags {
+		names := f.Names()
+		if len(names) == 0 || names[0] == "" {
+			v.errorf(path, "flag #%d has no name", i+1)
+			continue
+		}
+
+		for _, name := range names {
+			if name == "" {
+				continue
+			}
+			if seen[name] {
+				v.errorf(path, "flag name %q is defined more than once", name)
+			} else if owner, ok := inherited[name]; ok {
+				v.errorf(path, "flag name %q is already defined by the persistent flags of %s", name, owner)
+			}
+			seen[name] = true
+			if isFlagPersistent(f) {
+				persistent[name] = path
+			}
+		}
+
+		if rf, ok := f.(RequiredFlag); ok && rf.IsRequired() && hasDefaultValue(f) {
+			v.errorf(path, "required flag %q has a default value", names[0])
+		}
+
+		v.validateDestination(path, f)
+	}
+
+	return persistent
+}
+
+func (v *treeValidator) validateDestination(path string, f Flag) {
+	fv := flagValue(f)
+	if fv.Kind() != reflect.Struct {
+		return
+	}
+	dest := fv.FieldByName("Destination")
+	if !dest.IsValid() || dest.Kind() != reflect.Ptr || dest.IsNil() {
+		return
+	}
+
+	if owner, ok := v.destinations[dest.Pointer()]; ok && owner.flag != f {
+		v.errorf(path, "flag %q shares its destination with the flag %q of %s", f.Names()[0], owner.flag.Names()[0], owner.path)
+		return
+	}
+	v.destinations[dest.Pointer()] = flagOwner{path: path, flag: f}
+}
+
+// hasDefaultValue returns true if the Value field of the flag is set
+func hasDefaultValue(f Flag) bool {
+	fv := flagValue(f)
+	if fv.Kind() != reflect.Struct {
+		return false
+	}
+	value := fv.FieldByName("Value")
+	if !value.IsValid() || value.IsZero() {
+		return false
+	}
+	if df, ok := f.(DocGenerationFlag); ok && value.Kind() == reflect.Ptr {
+		// an empty slice or map is no default
+		return df.GetValue() != ""
+	}
+	return true
+}
+
+func (v *treeValidator) validateCommands(path string, commands []*Command, inherited map[string]string) {
+	seen := map[string]*Command{}
+	for i, c := range commands {
+		if c.Name == "" {
+			v.errorf(path, "command #%d has no name", i+1)
+			continue
+		}
+
+		for _, name := range c.Names() {
+			if other, ok := seen[name]; ok {
+				v.errorf(path, "command name %q of %q is already used by %q", name, c.Name, other.Name)
+				continue
+			}
+			seen[name] = c
+		}
+
+		cpath := path + " " + c.Name
+		persistent := v.validateFlags(cpath, c.Flags, inherited)
+		v.validateCommands(cpath, c.Subcommands, persistent)
+	}
+}